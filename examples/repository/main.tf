@@ -9,10 +9,11 @@ terraform {
 provider "dependencytrack" {}
 
 resource "dependencytrack_repository" "foo" {
-  url        = "https://foo.bar"
-  identifier = "foo"
-  enabled    = true
-  type       = "CPAN"
-  username   = "foo"
-  password   = "bar"
+  url              = "https://foo.bar"
+  identifier       = "foo"
+  enabled          = true
+  type             = "CPAN"
+  username         = "foo"
+  password         = "bar"
+  password_version = 1
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,11 +23,11 @@ func (d *configPropertiesDataSource) Configure(_ context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -64,6 +63,10 @@ func (d *configPropertiesDataSource) Schema(_ context.Context, _ datasource.Sche
 						"value": schema.StringAttribute{
 							Computed: true,
 						},
+						"fingerprint": schema.StringAttribute{
+							Description: "Fingerprint of the configuration property, changes whenever the property is changed.",
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -86,11 +89,12 @@ func (d *configPropertiesDataSource) Read(ctx context.Context, _ datasource.Read
 	// Map response body to model
 	for _, property := range properties {
 		configPropertyState := configPropertyModel{
-			ID:    types.StringValue(configPropertyID(property)),
-			Group: types.StringValue(property.GroupName),
-			Name:  types.StringValue(property.Name),
-			Type:  types.StringValue(property.Type),
-			Value: types.StringValue(property.Value),
+			ID:          types.StringValue(configPropertyID(property)),
+			Group:       types.StringValue(property.GroupName),
+			Name:        types.StringValue(property.Name),
+			Type:        types.StringValue(property.Type),
+			Value:       types.StringValue(property.Value),
+			Fingerprint: configPropertyFingerprint(property),
 		}
 
 		state.ConfigProperties = append(state.ConfigProperties, configPropertyState)
@@ -11,13 +11,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &configPropertyResource{}
-	_ resource.ResourceWithConfigure   = &configPropertyResource{}
-	_ resource.ResourceWithImportState = &configPropertyResource{}
+	_ resource.Resource                 = &configPropertyResource{}
+	_ resource.ResourceWithConfigure    = &configPropertyResource{}
+	_ resource.ResourceWithImportState  = &configPropertyResource{}
+	_ resource.ResourceWithModifyPlan   = &configPropertyResource{}
+	_ resource.ResourceWithUpgradeState = &configPropertyResource{}
 )
 
 // NewConfigPropertyResource is a helper function to simplify the provider implementation.
@@ -31,12 +34,12 @@ func (r *configPropertyResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -53,6 +56,7 @@ func (r *configPropertyResource) Metadata(_ context.Context, req resource.Metada
 // Schema defines the schema for the resource.
 func (r *configPropertyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -72,10 +76,53 @@ func (r *configPropertyResource) Schema(_ context.Context, _ resource.SchemaRequ
 			"value": schema.StringAttribute{
 				Required: true,
 			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the configuration property in DependencyTrack, changes when the property is changed outside of Terraform.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// UpgradeState upgrades the state of older schema versions.
+func (r *configPropertyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 had no fingerprint, it is set by the next refresh
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":    schema.StringAttribute{Computed: true},
+					"group": schema.StringAttribute{Required: true},
+					"name":  schema.StringAttribute{Required: true},
+					"type":  schema.StringAttribute{Required: true},
+					"value": schema.StringAttribute{Required: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior configPropertyModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, configPropertyModel{
+					ID:          prior.ID,
+					Group:       prior.Group,
+					Name:        prior.Name,
+					Value:       prior.Value,
+					Type:        prior.Type,
+					Fingerprint: types.StringNull(),
+				})...)
+			},
 		},
 	}
 }
 
+// ModifyPlan verifies the API token has the permissions required to manage configuration properties.
+func (r *configPropertyResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_config_property", dtrack.PermissionSystemConfiguration)...)
+}
+
 // Create creates the configProperty and sets the initial Terraform state.
 func (r *configPropertyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	resp.Diagnostics.AddError(
@@ -95,7 +142,12 @@ func (r *configPropertyResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	// Get refreshed order value from DependencyTrack
-	properties, err := r.client.Config.GetAll(ctx)
+	stateProperty, err := r.client.getConfigProperty(ctx, state.ID.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Configuration property not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading DependencyTrack Configuration Properties",
@@ -104,24 +156,13 @@ func (r *configPropertyResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	var stateProperty *dtrack.ConfigProperty
-	for _, property := range properties {
-		if state.ID.ValueString() == configPropertyID(property) {
-			stateProperty = &property
-		}
-	}
-
-	if stateProperty == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Overwrite items with refreshed state
-	state.ID = types.StringValue(configPropertyID(*stateProperty))
+	state.ID = types.StringValue(configPropertyID(stateProperty))
 	state.Group = types.StringValue(stateProperty.GroupName)
 	state.Name = types.StringValue(stateProperty.Name)
 	state.Type = types.StringValue(stateProperty.Type)
 	state.Value = types.StringValue(stateProperty.Value)
+	state.Fingerprint = configPropertyFingerprint(stateProperty)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -149,7 +190,7 @@ func (r *configPropertyResource) Update(ctx context.Context, req resource.Update
 	}
 
 	// Update existing configProperty
-	_, err := r.client.Config.Update(ctx, configProperty)
+	result, err := r.client.Config.Update(ctx, configProperty)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating config property",
@@ -158,7 +199,7 @@ func (r *configPropertyResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	// plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.Fingerprint = configPropertyFingerprint(result)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -178,3 +219,18 @@ func (r *configPropertyResource) ImportState(ctx context.Context, req resource.I
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// getConfigProperty returns the configuration property with the ID.
+// As the ID is not part of the API, a missing property wraps errNotFound.
+func (c *apiClient) getConfigProperty(ctx context.Context, id string) (dtrack.ConfigProperty, error) {
+	properties, err := c.Config.GetAll(ctx)
+	if err != nil {
+		return dtrack.ConfigProperty{}, err
+	}
+	for _, property := range properties {
+		if configPropertyID(property) == id {
+			return property, nil
+		}
+	}
+	return dtrack.ConfigProperty{}, fmt.Errorf("configuration property %s: %w", id, errNotFound)
+}
@@ -10,12 +10,12 @@ import (
 
 // configPropertiesDataSource is the datasource implementation.
 type configPropertiesDataSource struct {
-	client *dtrack.Client
+	client *apiClient
 }
 
 // configPropertyResource is the oidc group resource implementation.
 type configPropertyResource struct {
-	client *dtrack.Client
+	client *apiClient
 }
 
 // configPropertiesDataSourceModel maps the data source schema data.
@@ -25,6 +25,16 @@ type configPropertiesDataSourceModel struct {
 
 // configPropertyModel maps configuration property schema data.
 type configPropertyModel struct {
+	ID          types.String `tfsdk:"id"`
+	Group       types.String `tfsdk:"group"`
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	Type        types.String `tfsdk:"type"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+// configPropertyModelV0 maps the configuration property resource schema data of version 0, without fingerprint.
+type configPropertyModelV0 struct {
 	ID    types.String `tfsdk:"id"`
 	Group types.String `tfsdk:"group"`
 	Name  types.String `tfsdk:"name"`
@@ -35,3 +45,8 @@ type configPropertyModel struct {
 func configPropertyID(cp dtrack.ConfigProperty) string {
 	return fmt.Sprintf("%s_%s", cp.GroupName, strings.ReplaceAll(cp.Name, ".", "-"))
 }
+
+// configPropertyFingerprint returns the fingerprint of the configuration property.
+func configPropertyFingerprint(cp dtrack.ConfigProperty) types.String {
+	return fingerprint(cp.GroupName, cp.Name, cp.Type, cp.Value)
+}
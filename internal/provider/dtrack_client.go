@@ -0,0 +1,409 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/mod/semver"
+)
+
+// minimumServerVersion is the oldest DependencyTrack version the provider is tested against.
+const minimumServerVersion = "4.9.0"
+
+// apiClient wraps the DependencyTrack client with the information
+// gathered about the server while the provider is configured.
+type apiClient struct {
+	*dtrack.Client
+
+	httpClient  *http.Client
+	about       dtrack.About
+	permissions map[string]bool
+	cache       lookupCache
+	// adoptExisting is the provider default for the adopt_existing resource attribute.
+	adoptExisting bool
+}
+
+// clientConfig holds the settings used to connect to the DependencyTrack API.
+type clientConfig struct {
+	host               string
+	token              string
+	headers            map[string]string
+	caCertFile         string
+	clientCertFile     string
+	clientKeyFile      string
+	insecureSkipVerify bool
+	skipVersionCheck   bool
+	disableLookupCache bool
+	adoptExisting      bool
+	// maxConcurrentRequests limits the number of in-flight requests, no limit is applied if 0.
+	maxConcurrentRequests int64
+}
+
+// newAPIClient creates a DependencyTrack client. The server version is fetched separately by fetchVersion.
+func newAPIClient(cfg clientConfig) (*apiClient, error) {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{
+		Timeout:   dtrack.DefaultTimeout,
+		Transport: transport,
+	}
+
+	// The client fetches the server version when it is created. Without the version check,
+	// a failing version request is reported by fetchVersion instead.
+	var versionTransport *unknownVersionTransport
+	if cfg.skipVersionCheck {
+		versionTransport = &unknownVersionTransport{transport: transport}
+		httpClient.Transport = versionTransport
+	}
+	client, err := dtrack.NewClient(cfg.host, dtrack.WithHttpClient(httpClient), dtrack.WithAPIKey(cfg.token))
+	if versionTransport != nil {
+		versionTransport.created.Store(true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiClient{
+		Client:     client,
+		httpClient: httpClient,
+		cache: lookupCache{
+			disabled: cfg.disableLookupCache,
+			teams:    cachedList[dtrack.Team]{clone: cloneTeam},
+		},
+
+		adoptExisting: cfg.adoptExisting,
+	}, nil
+}
+
+// newTransport creates the http transport with the configured TLS settings and additional headers.
+func newTransport(cfg clientConfig) (http.RoundTripper, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- only disabled if explicitly configured
+		InsecureSkipVerify: cfg.insecureSkipVerify,
+	}
+
+	if cfg.caCertFile != "" {
+		caCert, err := os.ReadFile(cfg.caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ca cert file: %w", err)
+		}
+		certPool, _ := x509.SystemCertPool()
+		if certPool == nil {
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in ca cert file %q", cfg.caCertFile)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if cfg.clientCertFile != "" || cfg.clientKeyFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(cfg.clientCertFile, cfg.clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client key pair: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	var transport http.RoundTripper
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.TLSClientConfig = tlsConfig
+	transport = httpTransport
+
+	if cfg.maxConcurrentRequests > 0 {
+		transport = &limitingTransport{
+			slots:     make(chan struct{}, cfg.maxConcurrentRequests),
+			transport: transport,
+		}
+	}
+
+	if len(cfg.headers) == 0 {
+		return transport, nil
+	}
+	return &headerTransport{headers: cfg.headers, transport: transport}, nil
+}
+
+// limitingTransport bounds the number of in-flight requests.
+// A slot is held until the response body is closed.
+type limitingTransport struct {
+	slots     chan struct{}
+	transport http.RoundTripper
+}
+
+func (t *limitingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	tflog.Debug(req.Context(), "Acquired DependencyTrack request slot", map[string]any{
+		"url":       req.URL.Path,
+		"wait_time": time.Since(start).String(),
+	})
+
+	release := sync.OnceFunc(func() { <-t.slots })
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// releasingBody releases the request slot once the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// unknownVersionTransport answers failed requests for the server version with an unknown version
+// until the client is created.
+type unknownVersionTransport struct {
+	transport http.RoundTripper
+	created   atomic.Bool
+}
+
+func (t *unknownVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+	if t.created.Load() || !strings.HasSuffix(req.URL.Path, "/api/version") || (err == nil && res.StatusCode < http.StatusBadRequest) {
+		return res, err
+	}
+	if err == nil {
+		_ = res.Body.Close()
+	}
+	return &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+// headerTransport adds static headers to every request.
+type headerTransport struct {
+	headers   map[string]string
+	transport http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqCopy := req.Clone(req.Context())
+	for name, value := range t.headers {
+		reqCopy.Header.Set(name, value)
+	}
+	return t.transport.RoundTrip(reqCopy)
+}
+
+// fetchVersion fetches the version of the DependencyTrack server.
+// The version stays unknown if it can not be fetched.
+func (c *apiClient) fetchVersion(ctx context.Context) error {
+	about, err := c.About.Get(ctx)
+	if err != nil {
+		return err
+	}
+	c.about = about
+	return nil
+}
+
+// serverVersion returns the version reported by the DependencyTrack server, empty if unknown.
+func (c *apiClient) serverVersion() string {
+	return c.about.Version
+}
+
+// serverVersionAtLeast checks if the DependencyTrack server has at least the given version.
+// Unparsable server versions are treated as recent enough.
+func (c *apiClient) serverVersionAtLeast(version string) bool {
+	actual := normalizeVersion(c.about.Version)
+	if !semver.IsValid(actual) {
+		return true
+	}
+	return semver.Compare(actual, normalizeVersion(version)) >= 0
+}
+
+// checkServerVersion returns an error if the server version is unknown or below minimumServerVersion.
+func (c *apiClient) checkServerVersion() error {
+	if !semver.IsValid(normalizeVersion(c.about.Version)) {
+		return fmt.Errorf("the server reported the unrecognized version %q", c.about.Version)
+	}
+	if !c.serverVersionAtLeast(minimumServerVersion) {
+		return fmt.Errorf("the server version %s is below the minimum supported version %s", c.about.Version, minimumServerVersion)
+	}
+	return nil
+}
+
+// fetchPermissions performs an authenticated request to make sure the API token is accepted by the server
+// and records the permissions of the team the token belongs to.
+// The permissions stay unknown if the server version is unknown or the server can not report them.
+func (c *apiClient) fetchPermissions(ctx context.Context) error {
+	if c.about.Version == "" || !c.serverVersionAtLeast("4.11.0") {
+		return nil
+	}
+	var team dtrack.Team
+	err := c.getJSON(ctx, "/api/v1/team/self", &team)
+	if isNotFound(err) {
+		// the endpoint is not available on this server
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	c.permissions = make(map[string]bool)
+	for _, p := range team.Permissions {
+		c.permissions[p.Name] = true
+	}
+	return nil
+}
+
+// checkPermissions returns an error diagnostic for each of the required permissions the API token lacks to apply the plan.
+// No errors are returned if the permissions of the token are unknown or if the plan does not change anything,
+// so a read-only token can still plan resources without changes. Destroy plans are checked, as deleting writes too.
+func (c *apiClient) checkPermissions(req resource.ModifyPlanRequest, resourceType string, required ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if c == nil || c.permissions == nil || req.Plan.Raw.Equal(req.State.Raw) {
+		return diags
+	}
+	for _, p := range required {
+		if !c.permissions[p] {
+			diags.AddError(
+				"Missing DependencyTrack Permission",
+				fmt.Sprintf("The API token used by the provider is missing permission %s for resource %s. "+
+					"Grant the permission to the team of the API token and try again.", p, resourceType),
+			)
+		}
+	}
+	return diags
+}
+
+// adopt returns if an existing object should be adopted on creation.
+// The adopt_existing attribute of the resource takes precedence over the provider setting.
+func (c *apiClient) adopt(adoptExisting types.Bool) bool {
+	if !adoptExisting.IsNull() && !adoptExisting.IsUnknown() {
+		return adoptExisting.ValueBool()
+	}
+	return c.adoptExisting
+}
+
+// getJSON requests an API endpoint not covered by the DependencyTrack client and decodes the JSON response into v.
+// Non-successful responses are returned as *dtrack.APIError.
+func (c *apiClient) getJSON(ctx context.Context, path string, v any) error {
+	return c.doJSON(ctx, http.MethodGet, path, nil, v)
+}
+
+// doJSON sends body as JSON to an API endpoint not covered by the DependencyTrack client
+// and decodes the JSON response into v if v is not nil.
+// Non-successful responses are returned as *dtrack.APIError.
+func (c *apiClient) doJSON(ctx context.Context, method string, path string, body any, v any) error {
+	u, err := c.BaseURL().Parse(path)
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		b, _ := io.ReadAll(res.Body)
+		return &dtrack.APIError{StatusCode: res.StatusCode, Message: string(b)}
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// errNotFound is returned by lookups that find no matching object in a successful API response,
+// as opposed to the API reporting a missing object with a 404 response.
+var errNotFound = errors.New("not found")
+
+// isStatus checks if err is a DependencyTrack API error with the given status code.
+func isStatus(err error, statusCode int) bool {
+	var apiErr *dtrack.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// isNotFound checks if err reports a missing object, either as a DependencyTrack API error or as errNotFound.
+func isNotFound(err error) bool {
+	return errors.Is(err, errNotFound) || isStatus(err, http.StatusNotFound)
+}
+
+// describeConnectionError returns a summary and detail for an error that occurred while connecting to the server.
+func describeConnectionError(host string, err error) (string, string) {
+	var apiErr *dtrack.APIError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+		return "Invalid DependencyTrack API Token",
+			fmt.Sprintf("The DependencyTrack API at %q rejected the configured API token. "+
+				"Make sure the token is valid and has not been revoked.\n\n"+
+				"DependencyTrack Client Error: %v", host, err)
+	case errors.As(err, &apiErr):
+		return "Unexpected DependencyTrack API Response",
+			fmt.Sprintf("The DependencyTrack API at %q returned an unexpected response. "+
+				"Make sure the host points to the DependencyTrack API server and not to the frontend.\n\n"+
+				"DependencyTrack Client Error: %v", host, err)
+	case errors.As(err, &urlErr):
+		return "Unable to Connect to DependencyTrack",
+			fmt.Sprintf("The DependencyTrack API at %q could not be reached. "+
+				"Make sure the host is correct and the server is running.\n\n"+
+				"DependencyTrack Client Error: %v", host, err)
+	default:
+		return "Unable to Create DependencyTrack API Client",
+			"An unexpected error occurred when creating the DependencyTrack API client. " +
+				"If the error is not clear, please contact the provider developers.\n\n" +
+				"DependencyTrack Client Error: " + err.Error()
+	}
+}
+
+func normalizeVersion(version string) string {
+	return "v" + strings.TrimSuffix(version, "-SNAPSHOT")
+}
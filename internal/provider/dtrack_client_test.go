@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingServer answers requests to /fast right away and blocks all other requests until unblocked or cancelled.
+type blockingServer struct {
+	*httptest.Server
+	active    atomic.Int32
+	maxActive atomic.Int32
+	unblock   chan struct{}
+}
+
+func newBlockingServer(t *testing.T) *blockingServer {
+	s := &blockingServer{unblock: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.active.Add(1)
+		defer s.active.Add(-1)
+		for {
+			m := s.maxActive.Load()
+			if n <= m || s.maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		if r.URL.Path != "/fast" {
+			select {
+			case <-s.unblock:
+			case <-r.Context().Done():
+			}
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *blockingServer) get(ctx context.Context, client *http.Client, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, err = io.Copy(io.Discard, res.Body)
+	return err
+}
+
+// waitFor polls cond until it is true or fails the test after a second.
+func waitFor(t *testing.T, msg string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLimitingTransportLimitsConcurrentRequests(t *testing.T) {
+	server := newBlockingServer(t)
+	transport := &limitingTransport{slots: make(chan struct{}, 2), transport: http.DefaultTransport}
+	client := &http.Client{Transport: transport}
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := server.get(context.Background(), client, "/"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	waitFor(t, "expected 2 concurrent requests", func() bool { return server.active.Load() == 2 })
+	// Give the waiting requests a chance to exceed the limit
+	time.Sleep(50 * time.Millisecond)
+	if n := server.active.Load(); n != 2 {
+		t.Errorf("expected 2 concurrent requests, got %d", n)
+	}
+
+	close(server.unblock)
+	wg.Wait()
+	if n := server.maxActive.Load(); n != 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", n)
+	}
+	if n := len(transport.slots); n != 0 {
+		t.Errorf("expected all slots to be released, %d still held", n)
+	}
+}
+
+func TestLimitingTransportCancelledContext(t *testing.T) {
+	server := newBlockingServer(t)
+	defer close(server.unblock)
+	transport := &limitingTransport{slots: make(chan struct{}, 1), transport: http.DefaultTransport}
+	client := &http.Client{Transport: transport}
+
+	// The first request holds the only slot until it is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- server.get(ctx, client, "/")
+	}()
+	waitFor(t, "expected the first request to be in flight", func() bool { return server.active.Load() == 1 })
+
+	// A request waiting for a slot gives up once its context is cancelled
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+	if err := server.get(waitCtx, client, "/fast"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the waiting request to time out, got %v", err)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first request to be cancelled, got %v", err)
+	}
+
+	// The slot of the cancelled request is released
+	fastCtx, fastCancel := context.WithTimeout(context.Background(), time.Second)
+	defer fastCancel()
+	if err := server.get(fastCtx, client, "/fast"); err != nil {
+		t.Errorf("expected the slot of the cancelled request to be released, got %v", err)
+	}
+	if n := len(transport.slots); n != 0 {
+		t.Errorf("expected all slots to be released, %d still held", n)
+	}
+}
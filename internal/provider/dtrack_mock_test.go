@@ -0,0 +1,619 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+)
+
+// mockDependencyTrack is an in memory implementation of the team, permission, user, LDAP, OIDC, repository
+// and configuration property endpoints.
+type mockDependencyTrack struct {
+	mu           sync.Mutex
+	permissions  []dtrack.Permission
+	teams        map[uuid.UUID]*dtrack.Team
+	oidcGroups   map[uuid.UUID]dtrack.OIDCGroup
+	mappings     map[uuid.UUID]mockOIDCMapping
+	users        map[string]*mockUser
+	ldapMappings map[uuid.UUID]mockLdapMapping
+	// writes records the patterns of all requests changing the mock state
+	writes []string
+	// omitOIDCMappings omits the mapped OIDC groups from team lists, like servers not embedding them
+	omitOIDCMappings bool
+	// repos holds the repositories served by serveResponse, keyed by UUID
+	repos map[string]dtrack.Repository
+	// applyResolutionOrder applies the resolution order sent by clients on update, DependencyTrack ignores it
+	applyResolutionOrder bool
+	configProperties     []dtrack.ConfigProperty
+}
+
+// mockFailingPermission is known to the mock, but adding it to a team fails.
+const mockFailingPermission = dtrack.PermissionPolicyManagement
+
+type mockOIDCMapping struct {
+	group uuid.UUID
+	team  uuid.UUID
+}
+
+type mockLdapMapping struct {
+	dn   string
+	team uuid.UUID
+}
+
+// mockUser is a managed, LDAP or OIDC user known to the mock.
+type mockUser struct {
+	kind    string
+	teams   []uuid.UUID
+	subject string
+	email   string
+}
+
+// mockOIDCUserResponse maps an OIDC user as returned by the OIDC user endpoint.
+type mockOIDCUserResponse struct {
+	Username          string        `json:"username"`
+	SubjectIdentifier string        `json:"subjectIdentifier,omitempty"`
+	Email             string        `json:"email,omitempty"`
+	Teams             []dtrack.Team `json:"teams"`
+}
+
+// mockUsername maps a team member.
+type mockUsername struct {
+	Username string `json:"username"`
+}
+
+// mockTeamDetails maps a team with its members as returned by the team endpoint.
+type mockTeamDetails struct {
+	dtrack.Team
+	ManagedUsers     []mockUsername           `json:"managedUsers"`
+	LdapUsers        []mockUsername           `json:"ldapUsers"`
+	OidcUsers        []mockUsername           `json:"oidcUsers"`
+	MappedLdapGroups []dtrack.MappedLdapGroup `json:"mappedLdapGroups"`
+}
+
+const (
+	mockManagedUser = "managed"
+	mockLdapUser    = "ldap"
+	mockOIDCUser    = "oidc"
+)
+
+func newMockDependencyTrack() *mockDependencyTrack {
+	m := &mockDependencyTrack{
+		teams:      make(map[uuid.UUID]*dtrack.Team),
+		oidcGroups: make(map[uuid.UUID]dtrack.OIDCGroup),
+		mappings:   make(map[uuid.UUID]mockOIDCMapping),
+		users: map[string]*mockUser{
+			"alice": {kind: mockManagedUser},
+			"bob":   {kind: mockManagedUser},
+			"carol": {kind: mockLdapUser},
+			"dave":  {kind: mockOIDCUser, subject: "4f1c2d3e", email: "dave@example.com"},
+		},
+		ldapMappings: make(map[uuid.UUID]mockLdapMapping),
+		configProperties: []dtrack.ConfigProperty{
+			{GroupName: "general", Name: "base.url", Type: "URL", Value: "https://dtrack.example.com"},
+		},
+	}
+	for _, p := range []string{
+		dtrack.PermissionAccessManagement,
+		dtrack.PermissionBOMUpload,
+		dtrack.PermissionPortfolioManagement,
+		dtrack.PermissionSystemConfiguration,
+		mockFailingPermission,
+		dtrack.PermissionViewPortfolio,
+		dtrack.PermissionViewVulnerability,
+	} {
+		m.permissions = append(m.permissions, dtrack.Permission{Name: p})
+	}
+	return m
+}
+
+func (m *mockDependencyTrack) register(router *http.ServeMux) {
+	router.HandleFunc("GET /api/v1/permission", m.handle(func(_ *http.Request) (int, any) {
+		return http.StatusOK, m.permissions
+	}))
+	router.HandleFunc("GET /api/v1/configProperty", m.handle(func(_ *http.Request) (int, any) {
+		return http.StatusOK, m.configProperties
+	}))
+	router.HandleFunc("POST /api/v1/permission/{permission}/team/{team}", m.handle(m.changePermission(true)))
+	router.HandleFunc("DELETE /api/v1/permission/{permission}/team/{team}", m.handle(m.changePermission(false)))
+
+	router.HandleFunc("GET /api/v1/team", m.handle(func(_ *http.Request) (int, any) {
+		var teams []dtrack.Team
+		for _, t := range m.teams {
+			teams = append(teams, m.team(t))
+		}
+		slices.SortFunc(teams, func(a, b dtrack.Team) int { return strings.Compare(a.Name, b.Name) })
+		return http.StatusOK, teams
+	}))
+	router.HandleFunc("GET /api/v1/team/{team}", m.handle(func(r *http.Request) (int, any) {
+		t, ok := m.teams[uuid.MustParse(r.PathValue("team"))]
+		if !ok {
+			return http.StatusNotFound, nil
+		}
+		return http.StatusOK, m.teamDetails(t)
+	}))
+	router.HandleFunc("PUT /api/v1/team", m.handle(func(r *http.Request) (int, any) {
+		var team dtrack.Team
+		_ = json.NewDecoder(r.Body).Decode(&team)
+		t := &dtrack.Team{UUID: uuid.New(), Name: team.Name}
+		m.teams[t.UUID] = t
+		return http.StatusCreated, m.team(t)
+	}))
+	router.HandleFunc("POST /api/v1/team", m.handle(func(r *http.Request) (int, any) {
+		var team dtrack.Team
+		_ = json.NewDecoder(r.Body).Decode(&team)
+		t, ok := m.teams[team.UUID]
+		if !ok {
+			return http.StatusNotFound, nil
+		}
+		t.Name = team.Name
+		return http.StatusOK, m.teamDetails(t)
+	}))
+	router.HandleFunc("DELETE /api/v1/team", m.handle(func(r *http.Request) (int, any) {
+		var team dtrack.Team
+		_ = json.NewDecoder(r.Body).Decode(&team)
+		if _, ok := m.teams[team.UUID]; !ok {
+			return http.StatusNotFound, nil
+		}
+		delete(m.teams, team.UUID)
+		for id, mapping := range m.mappings {
+			if mapping.team == team.UUID {
+				delete(m.mappings, id)
+			}
+		}
+		for id, mapping := range m.ldapMappings {
+			if mapping.team == team.UUID {
+				delete(m.ldapMappings, id)
+			}
+		}
+		for _, u := range m.users {
+			u.teams = slices.DeleteFunc(u.teams, func(id uuid.UUID) bool { return id == team.UUID })
+		}
+		return http.StatusNoContent, nil
+	}))
+
+	router.HandleFunc("POST /api/v1/user/{username}/membership", m.handle(m.changeMembership(true)))
+	router.HandleFunc("DELETE /api/v1/user/{username}/membership", m.handle(m.changeMembership(false)))
+
+	router.HandleFunc("PUT /api/v1/ldap/mapping", m.handle(func(r *http.Request) (int, any) {
+		var req dtrack.MappedLdapGroupRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if _, ok := m.teams[req.Team]; !ok {
+			return http.StatusNotFound, nil
+		}
+		id := uuid.New()
+		m.ldapMappings[id] = mockLdapMapping{dn: req.DistinguishedName, team: req.Team}
+		return http.StatusOK, dtrack.MappedLdapGroup{UUID: id, DistinguishedName: req.DistinguishedName}
+	}))
+	router.HandleFunc("DELETE /api/v1/ldap/mapping/{mapping}", m.handle(func(r *http.Request) (int, any) {
+		id := uuid.MustParse(r.PathValue("mapping"))
+		if _, ok := m.ldapMappings[id]; !ok {
+			return http.StatusNotFound, nil
+		}
+		delete(m.ldapMappings, id)
+		return http.StatusNoContent, nil
+	}))
+
+	router.HandleFunc("GET /api/v1/user/oidc", m.handle(func(_ *http.Request) (int, any) {
+		var users []mockOIDCUserResponse
+		for _, name := range slices.Sorted(maps.Keys(m.users)) {
+			if u := m.users[name]; u.kind == mockOIDCUser {
+				users = append(users, m.oidcUser(name))
+			}
+		}
+		return http.StatusOK, users
+	}))
+	router.HandleFunc("PUT /api/v1/user/oidc", m.handle(func(r *http.Request) (int, any) {
+		var user mockOIDCUserResponse
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		if _, ok := m.users[user.Username]; ok {
+			return http.StatusConflict, nil
+		}
+		m.users[user.Username] = &mockUser{kind: mockOIDCUser}
+		return http.StatusCreated, m.oidcUser(user.Username)
+	}))
+	router.HandleFunc("DELETE /api/v1/user/oidc", m.handle(func(r *http.Request) (int, any) {
+		var user mockOIDCUserResponse
+		_ = json.NewDecoder(r.Body).Decode(&user)
+		if u, ok := m.users[user.Username]; !ok || u.kind != mockOIDCUser {
+			return http.StatusNotFound, nil
+		}
+		delete(m.users, user.Username)
+		return http.StatusNoContent, nil
+	}))
+
+	router.HandleFunc("GET /api/v1/oidc/group", m.handle(func(_ *http.Request) (int, any) {
+		var groups []dtrack.OIDCGroup
+		for _, g := range m.oidcGroups {
+			groups = append(groups, g)
+		}
+		slices.SortFunc(groups, func(a, b dtrack.OIDCGroup) int { return strings.Compare(a.Name, b.Name) })
+		return http.StatusOK, groups
+	}))
+	router.HandleFunc("PUT /api/v1/oidc/group", m.handle(func(r *http.Request) (int, any) {
+		var group dtrack.OIDCGroup
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		group.UUID = uuid.New()
+		m.oidcGroups[group.UUID] = group
+		return http.StatusCreated, group
+	}))
+	router.HandleFunc("POST /api/v1/oidc/group", m.handle(func(r *http.Request) (int, any) {
+		var group dtrack.OIDCGroup
+		_ = json.NewDecoder(r.Body).Decode(&group)
+		if _, ok := m.oidcGroups[group.UUID]; !ok {
+			return http.StatusNotFound, nil
+		}
+		m.oidcGroups[group.UUID] = group
+		return http.StatusOK, group
+	}))
+	router.HandleFunc("DELETE /api/v1/oidc/group/{group}", m.handle(func(r *http.Request) (int, any) {
+		id := uuid.MustParse(r.PathValue("group"))
+		if _, ok := m.oidcGroups[id]; !ok {
+			return http.StatusNotFound, nil
+		}
+		delete(m.oidcGroups, id)
+		for mid, mapping := range m.mappings {
+			if mapping.group == id {
+				delete(m.mappings, mid)
+			}
+		}
+		return http.StatusNoContent, nil
+	}))
+	router.HandleFunc("GET /api/v1/oidc/group/{group}/team", m.handle(func(r *http.Request) (int, any) {
+		id := uuid.MustParse(r.PathValue("group"))
+		var teams []dtrack.Team
+		for _, mapping := range m.mappings {
+			if mapping.group == id {
+				teams = append(teams, m.team(m.teams[mapping.team]))
+			}
+		}
+		return http.StatusOK, teams
+	}))
+	router.HandleFunc("PUT /api/v1/oidc/mapping", m.handle(func(r *http.Request) (int, any) {
+		var req dtrack.OIDCMappingRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		group, ok := m.oidcGroups[req.Group]
+		if _, teamOK := m.teams[req.Team]; !ok || !teamOK {
+			return http.StatusNotFound, nil
+		}
+		for _, mapping := range m.mappings {
+			if mapping.group == req.Group && mapping.team == req.Team {
+				return http.StatusConflict, nil
+			}
+		}
+		id := uuid.New()
+		m.mappings[id] = mockOIDCMapping{group: req.Group, team: req.Team}
+		return http.StatusOK, dtrack.OIDCMapping{UUID: id, Group: group}
+	}))
+	router.HandleFunc("DELETE /api/v1/oidc/mapping/{mapping}", m.handle(func(r *http.Request) (int, any) {
+		id := uuid.MustParse(r.PathValue("mapping"))
+		if _, ok := m.mappings[id]; !ok {
+			return http.StatusNotFound, nil
+		}
+		delete(m.mappings, id)
+		return http.StatusNoContent, nil
+	}))
+}
+
+// handle serializes access to the mock state and writes the returned body as JSON.
+func (m *mockDependencyTrack) handle(h func(r *http.Request) (int, any)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if request.Method != http.MethodGet {
+			m.writes = append(m.writes, request.Pattern)
+		}
+		status, body := h(request)
+		if body == nil {
+			writer.WriteHeader(status)
+			return
+		}
+		b, _ := json.Marshal(body)
+		if v, ok := body.([]dtrack.Team); ok {
+			writer.Header().Set("X-Total-Count", fmt.Sprintf("%d", len(v)))
+		}
+		if v, ok := body.([]dtrack.OIDCGroup); ok {
+			writer.Header().Set("X-Total-Count", fmt.Sprintf("%d", len(v)))
+		}
+		if v, ok := body.([]dtrack.Permission); ok {
+			writer.Header().Set("X-Total-Count", fmt.Sprintf("%d", len(v)))
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(status)
+		_, _ = writer.Write(b)
+	}
+}
+
+func (m *mockDependencyTrack) changePermission(add bool) func(r *http.Request) (int, any) {
+	return func(r *http.Request) (int, any) {
+		t, ok := m.teams[uuid.MustParse(r.PathValue("team"))]
+		name := r.PathValue("permission")
+		if !ok || !slices.ContainsFunc(m.permissions, func(p dtrack.Permission) bool { return p.Name == name }) {
+			return http.StatusNotFound, nil
+		}
+		if add && name == mockFailingPermission {
+			return http.StatusInternalServerError, nil
+		}
+		has := slices.ContainsFunc(t.Permissions, func(p dtrack.Permission) bool { return p.Name == name })
+		switch {
+		case add && has, !add && !has:
+			return http.StatusNotModified, nil
+		case add:
+			t.Permissions = append(t.Permissions, dtrack.Permission{Name: name})
+		default:
+			t.Permissions = slices.DeleteFunc(t.Permissions, func(p dtrack.Permission) bool { return p.Name == name })
+		}
+		return http.StatusOK, m.team(t)
+	}
+}
+
+func (m *mockDependencyTrack) changeMembership(add bool) func(r *http.Request) (int, any) {
+	return func(r *http.Request) (int, any) {
+		var team struct {
+			UUID uuid.UUID `json:"uuid"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&team)
+		u, ok := m.users[r.PathValue("username")]
+		if _, teamOK := m.teams[team.UUID]; !ok || !teamOK {
+			return http.StatusNotFound, nil
+		}
+		has := slices.Contains(u.teams, team.UUID)
+		switch {
+		case add && has, !add && !has:
+			return http.StatusNotModified, nil
+		case add:
+			u.teams = append(u.teams, team.UUID)
+		default:
+			u.teams = slices.DeleteFunc(u.teams, func(id uuid.UUID) bool { return id == team.UUID })
+		}
+		return http.StatusOK, mockUsername{Username: r.PathValue("username")}
+	}
+}
+
+// takeWrites returns the recorded write requests and resets the recording.
+func (m *mockDependencyTrack) takeWrites() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writes := m.writes
+	m.writes = nil
+	return writes
+}
+
+// addTeam adds a team as if it was created outside of Terraform.
+func (m *mockDependencyTrack) addTeam(name string, permissions ...string) uuid.UUID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := &dtrack.Team{UUID: uuid.New(), Name: name}
+	for _, p := range permissions {
+		t.Permissions = append(t.Permissions, dtrack.Permission{Name: p})
+	}
+	m.teams[t.UUID] = t
+	return t.UUID
+}
+
+// addAPIKey adds an API key with the given comment to the team.
+func (m *mockDependencyTrack) addAPIKey(teamID uuid.UUID, comment string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := m.teams[teamID]
+	t.APIKeys = append(t.APIKeys, dtrack.APIKey{PublicId: uuid.NewString()[:8], Comment: comment})
+}
+
+// deleteTeam deletes a team as if it was deleted outside of Terraform.
+func (m *mockDependencyTrack) deleteTeam(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, t := range m.teams {
+		if t.Name == name {
+			delete(m.teams, id)
+		}
+	}
+}
+
+// hasTeam returns if a team with the given name exists.
+func (m *mockDependencyTrack) hasTeam(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.teams {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPermission returns if the team with the given name has the permission.
+func (m *mockDependencyTrack) hasPermission(teamName string, permission string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.teams {
+		if t.Name == teamName {
+			return slices.ContainsFunc(t.Permissions, func(p dtrack.Permission) bool { return p.Name == permission })
+		}
+	}
+	return false
+}
+
+// addOIDCGroup adds an OIDC group as if it was created outside of Terraform.
+func (m *mockDependencyTrack) addOIDCGroup(name string) uuid.UUID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	group := dtrack.OIDCGroup{UUID: uuid.New(), Name: name}
+	m.oidcGroups[group.UUID] = group
+	return group.UUID
+}
+
+// renameOIDCGroup renames the OIDC group as if it was done outside of Terraform.
+func (m *mockDependencyTrack) renameOIDCGroup(name string, newName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, g := range m.oidcGroups {
+		if g.Name == name {
+			g.Name = newName
+			m.oidcGroups[id] = g
+		}
+	}
+}
+
+// hasOIDCGroup returns if an OIDC group with the given name exists.
+func (m *mockDependencyTrack) hasOIDCGroup(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, g := range m.oidcGroups {
+		if g.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setResolutionOrder changes the resolution order of the repository as if it was done outside of Terraform.
+func (m *mockDependencyTrack) setResolutionOrder(id string, order int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	repo := m.repos[id]
+	repo.ResolutionOrder = order
+	m.repos[id] = repo
+}
+
+// resolutionOrder returns the resolution order of the repository.
+func (m *mockDependencyTrack) resolutionOrder(id string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.repos[id].ResolutionOrder
+}
+
+// repositoryPassword returns the password of the repository.
+func (m *mockDependencyTrack) repositoryPassword(id string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.repos[id].Password
+}
+
+// setApplyResolutionOrder sets if the resolution order sent by clients on update is applied.
+func (m *mockDependencyTrack) setApplyResolutionOrder(apply bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.applyResolutionOrder = apply
+}
+
+// setOmitOIDCMappings sets if the mapped OIDC groups are omitted from team lists. The team details always include them.
+func (m *mockDependencyTrack) setOmitOIDCMappings(omit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.omitOIDCMappings = omit
+}
+
+// addOIDCMapping maps the OIDC group to the team as if it was done outside of Terraform.
+func (m *mockDependencyTrack) addOIDCMapping(groupName string, teamID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, g := range m.oidcGroups {
+		if g.Name == groupName {
+			m.mappings[uuid.New()] = mockOIDCMapping{group: g.UUID, team: teamID}
+		}
+	}
+}
+
+// removeOIDCMappings removes all team mappings of the OIDC group as if it was done outside of Terraform.
+func (m *mockDependencyTrack) removeOIDCMappings(groupName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, mapping := range m.mappings {
+		if m.oidcGroups[mapping.group].Name == groupName {
+			delete(m.mappings, id)
+		}
+	}
+}
+
+// addLdapMapping maps the LDAP group to the team as if it was done outside of Terraform.
+func (m *mockDependencyTrack) addLdapMapping(dn string, teamID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ldapMappings[uuid.New()] = mockLdapMapping{dn: dn, team: teamID}
+}
+
+// addMember adds the user to the team as if it was done outside of Terraform.
+func (m *mockDependencyTrack) addMember(username string, teamName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.teams {
+		if t.Name == teamName {
+			m.users[username].teams = append(m.users[username].teams, t.UUID)
+		}
+	}
+}
+
+// isMember returns if the user is member of the team.
+func (m *mockDependencyTrack) isMember(username string, teamName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.ContainsFunc(m.users[username].teams, func(id uuid.UUID) bool {
+		return m.teams[id].Name == teamName
+	})
+}
+
+// oidcUser returns the OIDC user with its teams.
+func (m *mockDependencyTrack) oidcUser(username string) mockOIDCUserResponse {
+	u := m.users[username]
+	user := mockOIDCUserResponse{Username: username, SubjectIdentifier: u.subject, Email: u.email, Teams: []dtrack.Team{}}
+	for _, id := range u.teams {
+		user.Teams = append(user.Teams, m.team(m.teams[id]))
+	}
+	return user
+}
+
+// teamDetails returns a copy of the team with its members and group mappings.
+func (m *mockDependencyTrack) teamDetails(t *dtrack.Team) mockTeamDetails {
+	team := mockTeamDetails{Team: m.team(t)}
+	team.MappedOIDCGroups = m.oidcMappings(t.UUID)
+	for _, name := range slices.Sorted(maps.Keys(m.users)) {
+		u := m.users[name]
+		if !slices.Contains(u.teams, t.UUID) {
+			continue
+		}
+		switch u.kind {
+		case mockManagedUser:
+			team.ManagedUsers = append(team.ManagedUsers, mockUsername{Username: name})
+		case mockLdapUser:
+			team.LdapUsers = append(team.LdapUsers, mockUsername{Username: name})
+		case mockOIDCUser:
+			team.OidcUsers = append(team.OidcUsers, mockUsername{Username: name})
+		}
+	}
+	for id, mapping := range m.ldapMappings {
+		if mapping.team == t.UUID {
+			team.MappedLdapGroups = append(team.MappedLdapGroups, dtrack.MappedLdapGroup{UUID: id, DistinguishedName: mapping.dn})
+		}
+	}
+	return team
+}
+
+// team returns a copy of the team with its OIDC group mappings, unless they are omitted.
+func (m *mockDependencyTrack) team(t *dtrack.Team) dtrack.Team {
+	team := *t
+	team.Permissions = slices.Clone(t.Permissions)
+	team.MappedOIDCGroups = nil
+	if !m.omitOIDCMappings {
+		team.MappedOIDCGroups = m.oidcMappings(t.UUID)
+	}
+	return team
+}
+
+// oidcMappings returns the OIDC group mappings of the team.
+func (m *mockDependencyTrack) oidcMappings(teamID uuid.UUID) []dtrack.OIDCMapping {
+	var mappings []dtrack.OIDCMapping
+	for id, mapping := range m.mappings {
+		if mapping.team == teamID {
+			mappings = append(mappings, dtrack.OIDCMapping{UUID: id, Group: m.oidcGroups[mapping.group]})
+		}
+	}
+	return mappings
+}
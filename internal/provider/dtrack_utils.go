@@ -1,20 +1,17 @@
 package provider
 
 import (
-	dtrack "github.com/DependencyTrack/client-go"
-)
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
 
-func fetchAllMappedByUI[T any](
-	pageFetchFunc func(po dtrack.PageOptions) (dtrack.Page[T], error),
-	idOf func(it T) string,
-) (map[string]T, error) {
-	res, err := dtrack.FetchAll(pageFetchFunc)
-	if err != nil {
-		return nil, err
-	}
-	m := mapByID[T](res, idOf)
-	return m, nil
-}
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func mapByID[T any](res []T, idOf func(it T) string) map[string]T {
 	m := make(map[string]T)
@@ -24,3 +21,35 @@ func mapByID[T any](res []T, idOf func(it T) string) map[string]T {
 	}
 	return m
 }
+
+// parseUUID parses the UUID of the attribute at p. Malformed values, e.g. from corrupt state,
+// are returned as attribute error instead of panicking like uuid.MustParse.
+func parseUUID(p path.Path, value types.String) (uuid.UUID, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	id, err := uuid.Parse(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid UUID",
+			fmt.Sprintf("The value %q of attribute %s is not a valid UUID: %v", value.ValueString(), p, err),
+		)
+	}
+	return id, diags
+}
+
+// fingerprint returns a SHA-256 digest of the JSON representation of content, the server-side state of an object.
+// Changes made outside of Terraform change the fingerprint and show up in plans. Slices in content whose order is
+// not significant must be sorted, e.g. with sortedStrings.
+func fingerprint(content ...any) types.String {
+	// content only consists of plain values and structs of them, which always marshal
+	b, _ := json.Marshal(content)
+	sum := sha256.Sum256(b)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+// sortedStrings returns a sorted copy of values.
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
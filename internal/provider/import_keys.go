@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importNamePrefix marks import IDs referencing teams or OIDC groups by name, e.g. "name:Developers".
+const importNamePrefix = "name:"
+
+// parseNameImportID returns the name of an import ID like "name:Developers".
+func parseNameImportID(id string) (string, bool) {
+	name, ok := strings.CutPrefix(id, importNamePrefix)
+	return name, ok && name != ""
+}
+
+// parseRepositoryImportID returns type and identifier of an import ID like "GO_MODULES/proxy.golang.org".
+// UUIDs are not parsed as natural key.
+func parseRepositoryImportID(id string) (dtrack.RepositoryType, string, bool) {
+	if _, err := uuid.Parse(id); err == nil {
+		return "", "", false
+	}
+	repoType, identifier, ok := strings.Cut(id, "/")
+	if !ok || repoType == "" || identifier == "" {
+		return "", "", false
+	}
+	return dtrack.RepositoryType(repoType), identifier, true
+}
+
+// importStateUUID validates the import ID is a UUID and saves it to the id attribute.
+// The expected formats are listed in the error returned for other IDs.
+func importStateUUID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, formats string) {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected %s, got: %q", formats, req.ID),
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// lookupCache caches the collections resources look up repeatedly during a single Terraform run.
+// It is safe for concurrent use; concurrent lookups of the same collection share a single fetch.
+// Resources update the cached items after changing them, so the collections are not fetched again.
+type lookupCache struct {
+	disabled    bool
+	teams       cachedList[dtrack.Team]
+	permissions cachedList[dtrack.Permission]
+	oidcGroups  cachedList[dtrack.OIDCGroup]
+}
+
+// cachedList holds a lazily fetched collection.
+type cachedList[T any] struct {
+	mu    sync.Mutex
+	items []T
+	valid bool
+	// clone copies the nested slices of an item, so callers can not change the cached items. Items are copied as is if nil.
+	clone func(T) T
+}
+
+// get returns the cached items, fetching them if not cached yet or if caching is disabled.
+func (l *cachedList[T]) get(disabled bool, fetch func() ([]T, error)) ([]T, error) {
+	if disabled {
+		return fetch()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.valid {
+		items, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		l.items = items
+		l.valid = true
+	}
+	return l.copyItems(l.items), nil
+}
+
+// update replaces the cached items with the result of fn, if the items are cached.
+// fn is called with a copy of the cached items.
+func (l *cachedList[T]) update(fn func(items []T) []T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.valid {
+		l.items = fn(l.copyItems(l.items))
+	}
+}
+
+// copyItems returns a copy of the items not sharing any slices with them.
+func (l *cachedList[T]) copyItems(items []T) []T {
+	items = slices.Clone(items)
+	if l.clone != nil {
+		for i := range items {
+			items[i] = l.clone(items[i])
+		}
+	}
+	return items
+}
+
+// invalidate drops the cached items, so the next lookup fetches them again.
+func (l *cachedList[T]) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items = nil
+	l.valid = false
+}
+
+// allTeams returns all teams.
+func (c *apiClient) allTeams(ctx context.Context) ([]dtrack.Team, error) {
+	return c.cache.teams.get(c.cache.disabled, func() ([]dtrack.Team, error) {
+		return dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
+			return c.Team.GetAll(ctx, po)
+		})
+	})
+}
+
+// allPermissions returns all permissions known to the server.
+func (c *apiClient) allPermissions(ctx context.Context) ([]dtrack.Permission, error) {
+	return c.cache.permissions.get(c.cache.disabled, func() ([]dtrack.Permission, error) {
+		return dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Permission], error) {
+			return c.Permission.GetAll(ctx, po)
+		})
+	})
+}
+
+// allOIDCGroups returns all OIDC groups.
+func (c *apiClient) allOIDCGroups(ctx context.Context) ([]dtrack.OIDCGroup, error) {
+	return c.cache.oidcGroups.get(c.cache.disabled, func() ([]dtrack.OIDCGroup, error) {
+		return dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.OIDCGroup], error) {
+			return c.OIDC.GetAllGroups(ctx, po)
+		})
+	})
+}
+
+// invalidateTeams has to be called after teams, their permissions or their mappings were changed
+// in a way not covered by the cache updates below.
+func (c *apiClient) invalidateTeams() {
+	c.cache.teams.invalidate()
+}
+
+// invalidateOIDCGroups has to be called after OIDC groups were changed in a way not covered by the cache updates below.
+func (c *apiClient) invalidateOIDCGroups() {
+	c.cache.oidcGroups.invalidate()
+}
+
+// invalidateOnError drops the cached teams and OIDC groups if diags has errors,
+// as a failed change might be applied partially. It is meant to be deferred by operations changing them.
+func (c *apiClient) invalidateOnError(diags *diag.Diagnostics) {
+	if diags.HasError() {
+		c.invalidateTeams()
+		c.invalidateOIDCGroups()
+	}
+}
+
+// cacheTeam stores the team in the cached teams, replacing a cached team with the same UUID.
+func (c *apiClient) cacheTeam(team dtrack.Team) {
+	team = cloneTeam(team)
+	c.cache.teams.update(func(teams []dtrack.Team) []dtrack.Team {
+		if i := slices.IndexFunc(teams, func(t dtrack.Team) bool { return t.UUID == team.UUID }); i >= 0 {
+			teams[i] = team
+			return teams
+		}
+		return append(teams, team)
+	})
+}
+
+// uncacheTeam removes the team from the cached teams.
+func (c *apiClient) uncacheTeam(id uuid.UUID) {
+	c.cache.teams.update(func(teams []dtrack.Team) []dtrack.Team {
+		return slices.DeleteFunc(teams, func(t dtrack.Team) bool { return t.UUID == id })
+	})
+}
+
+// cacheOIDCGroup stores the OIDC group in the cached groups, replacing a cached group with the same UUID,
+// and updates the name of the group in the cached team mappings.
+func (c *apiClient) cacheOIDCGroup(group dtrack.OIDCGroup) {
+	c.cache.oidcGroups.update(func(groups []dtrack.OIDCGroup) []dtrack.OIDCGroup {
+		if i := slices.IndexFunc(groups, func(g dtrack.OIDCGroup) bool { return g.UUID == group.UUID }); i >= 0 {
+			groups[i] = group
+			return groups
+		}
+		return append(groups, group)
+	})
+	c.cache.teams.update(func(teams []dtrack.Team) []dtrack.Team {
+		for i := range teams {
+			for j := range teams[i].MappedOIDCGroups {
+				if teams[i].MappedOIDCGroups[j].Group.UUID == group.UUID {
+					teams[i].MappedOIDCGroups[j].Group = group
+				}
+			}
+		}
+		return teams
+	})
+}
+
+// uncacheOIDCGroup removes the OIDC group and its mappings from the cache.
+func (c *apiClient) uncacheOIDCGroup(id uuid.UUID) {
+	c.cache.oidcGroups.update(func(groups []dtrack.OIDCGroup) []dtrack.OIDCGroup {
+		return slices.DeleteFunc(groups, func(g dtrack.OIDCGroup) bool { return g.UUID == id })
+	})
+	c.cache.teams.update(func(teams []dtrack.Team) []dtrack.Team {
+		for i := range teams {
+			teams[i].MappedOIDCGroups = slices.DeleteFunc(teams[i].MappedOIDCGroups, func(m dtrack.OIDCMapping) bool { return m.Group.UUID == id })
+		}
+		return teams
+	})
+}
+
+// cacheOIDCMapping adds the mapping of the group to the mappings of the cached team.
+// If the group of the mapping is unknown, the cached teams are dropped instead.
+func (c *apiClient) cacheOIDCMapping(teamUUID uuid.UUID, group dtrack.OIDCGroup, mapping dtrack.OIDCMapping) {
+	if mapping.Group.UUID == uuid.Nil {
+		mapping.Group = group
+	}
+	if mapping.Group.Name == "" {
+		c.invalidateTeams()
+		return
+	}
+	c.cache.teams.update(func(teams []dtrack.Team) []dtrack.Team {
+		for i := range teams {
+			if teams[i].UUID == teamUUID {
+				teams[i].MappedOIDCGroups = append(teams[i].MappedOIDCGroups, mapping)
+			}
+		}
+		return teams
+	})
+}
+
+// uncacheOIDCMapping removes the mapping from the mappings of the cached teams.
+func (c *apiClient) uncacheOIDCMapping(id uuid.UUID) {
+	c.cache.teams.update(func(teams []dtrack.Team) []dtrack.Team {
+		for i := range teams {
+			teams[i].MappedOIDCGroups = slices.DeleteFunc(teams[i].MappedOIDCGroups, func(m dtrack.OIDCMapping) bool { return m.UUID == id })
+		}
+		return teams
+	})
+}
+
+// cloneTeam returns a copy of the team not sharing the nested slices with it.
+func cloneTeam(team dtrack.Team) dtrack.Team {
+	team.APIKeys = slices.Clone(team.APIKeys)
+	team.Permissions = slices.Clone(team.Permissions)
+	team.MappedOIDCGroups = slices.Clone(team.MappedOIDCGroups)
+	return team
+}
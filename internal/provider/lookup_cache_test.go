@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func fetchTeams(fetches *atomic.Int32, teams ...dtrack.Team) func() ([]dtrack.Team, error) {
+	return func() ([]dtrack.Team, error) {
+		fetches.Add(1)
+		return teams, nil
+	}
+}
+
+func TestCachedListHit(t *testing.T) {
+	var fetches atomic.Int32
+	var l cachedList[dtrack.Team]
+	fetch := fetchTeams(&fetches, dtrack.Team{Name: "Developers"})
+
+	for range 3 {
+		teams, err := l.get(false, fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(teams) != 1 || teams[0].Name != "Developers" {
+			t.Fatalf("unexpected teams %v", teams)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected a single fetch, got %d", n)
+	}
+}
+
+func TestCachedListDisabled(t *testing.T) {
+	var fetches atomic.Int32
+	var l cachedList[dtrack.Team]
+	fetch := fetchTeams(&fetches)
+
+	for range 3 {
+		if _, err := l.get(true, fetch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := fetches.Load(); n != 3 {
+		t.Errorf("expected 3 fetches, got %d", n)
+	}
+}
+
+func TestCachedListInvalidate(t *testing.T) {
+	var fetches atomic.Int32
+	var l cachedList[dtrack.Team]
+	fetch := fetchTeams(&fetches)
+
+	if _, err := l.get(false, fetch); err != nil {
+		t.Fatal(err)
+	}
+	l.invalidate()
+	if _, err := l.get(false, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected 2 fetches, got %d", n)
+	}
+}
+
+func TestCachedListFetchError(t *testing.T) {
+	var l cachedList[dtrack.Team]
+	_, err := l.get(false, func() ([]dtrack.Team, error) { return nil, errors.New("unavailable") })
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var fetches atomic.Int32
+	if _, err := l.get(false, fetchTeams(&fetches)); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected the failed fetch not to be cached, got %d fetches", n)
+	}
+}
+
+func TestCachedListDeepCopy(t *testing.T) {
+	var fetches atomic.Int32
+	l := cachedList[dtrack.Team]{clone: cloneTeam}
+	fetch := fetchTeams(&fetches, dtrack.Team{Name: "Developers", Permissions: []dtrack.Permission{{Name: "VIEW_PORTFOLIO"}}})
+
+	teams, err := l.get(false, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teams[0].Name = "Changed"
+	teams[0].Permissions[0].Name = "Changed"
+
+	teams, err = l.get(false, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if teams[0].Name != "Developers" || teams[0].Permissions[0].Name != "VIEW_PORTFOLIO" {
+		t.Errorf("expected the cached team to be unchanged, got %v", teams[0])
+	}
+}
+
+func TestCachedListUpdateNotCached(t *testing.T) {
+	var l cachedList[dtrack.Team]
+	l.update(func(teams []dtrack.Team) []dtrack.Team {
+		t.Error("expected update not to be called without cached items")
+		return teams
+	})
+
+	var fetches atomic.Int32
+	teams, err := l.get(false, fetchTeams(&fetches))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 0 || fetches.Load() != 1 {
+		t.Errorf("expected the teams to be fetched, got %v", teams)
+	}
+}
+
+func TestCachedListConcurrentAccess(t *testing.T) {
+	var fetches atomic.Int32
+	l := cachedList[dtrack.Team]{clone: cloneTeam}
+	fetch := fetchTeams(&fetches)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := l.get(false, fetch); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			l.update(func(teams []dtrack.Team) []dtrack.Team {
+				return append(teams, dtrack.Team{UUID: uuid.New(), Permissions: []dtrack.Permission{{Name: "VIEW_PORTFOLIO"}}})
+			})
+			if i%5 == 0 {
+				l.invalidate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := fetches.Load(); n < 1 || n > 5 {
+		t.Errorf("expected between 1 and 5 fetches, got %d", n)
+	}
+}
+
+func TestCacheUpdates(t *testing.T) {
+	c := &apiClient{cache: lookupCache{teams: cachedList[dtrack.Team]{clone: cloneTeam}}}
+	developers := dtrack.Team{UUID: uuid.New(), Name: "Developers"}
+	group := dtrack.OIDCGroup{UUID: uuid.New(), Name: "developers"}
+	mapping := dtrack.OIDCMapping{UUID: uuid.New()}
+
+	var teamFetches, groupFetches atomic.Int32
+	teams := func() []dtrack.Team {
+		t.Helper()
+		teams, err := c.cache.teams.get(false, fetchTeams(&teamFetches, developers))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return teams
+	}
+	groups := func() []dtrack.OIDCGroup {
+		t.Helper()
+		groups, err := c.cache.oidcGroups.get(false, func() ([]dtrack.OIDCGroup, error) {
+			groupFetches.Add(1)
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return groups
+	}
+	teams()
+	groups()
+
+	c.cacheOIDCGroup(group)
+	if got := groups(); len(got) != 1 || got[0] != group {
+		t.Errorf("expected the created group to be cached, got %v", got)
+	}
+
+	c.cacheOIDCMapping(developers.UUID, group, mapping)
+	if got := teams(); len(got[0].MappedOIDCGroups) != 1 || got[0].MappedOIDCGroups[0].Group != group {
+		t.Errorf("expected the mapping to be cached, got %v", got)
+	}
+
+	group.Name = "engineers"
+	c.cacheOIDCGroup(group)
+	if got := teams(); got[0].MappedOIDCGroups[0].Group.Name != "engineers" {
+		t.Errorf("expected the mapped group to be renamed, got %v", got)
+	}
+
+	c.uncacheOIDCMapping(mapping.UUID)
+	if got := teams(); len(got[0].MappedOIDCGroups) != 0 {
+		t.Errorf("expected the mapping to be removed, got %v", got)
+	}
+
+	c.cacheOIDCMapping(developers.UUID, group, mapping)
+	c.uncacheOIDCGroup(group.UUID)
+	if got := groups(); len(got) != 0 {
+		t.Errorf("expected the group to be removed, got %v", got)
+	}
+	if got := teams(); len(got[0].MappedOIDCGroups) != 0 {
+		t.Errorf("expected the mappings of the group to be removed, got %v", got)
+	}
+
+	developers.Permissions = []dtrack.Permission{{Name: "VIEW_PORTFOLIO"}}
+	c.cacheTeam(developers)
+	if got := teams(); len(got) != 1 || len(got[0].Permissions) != 1 {
+		t.Errorf("expected the team to be replaced, got %v", got)
+	}
+	c.uncacheTeam(developers.UUID)
+	if got := teams(); len(got) != 0 {
+		t.Errorf("expected the team to be removed, got %v", got)
+	}
+
+	if teamFetches.Load() != 1 || groupFetches.Load() != 1 {
+		t.Errorf("expected the cache to be updated in place, got %d team and %d group fetches", teamFetches.Load(), groupFetches.Load())
+	}
+}
+
+func TestCacheInvalidateOnError(t *testing.T) {
+	c := &apiClient{}
+	var fetches atomic.Int32
+	fetch := fetchTeams(&fetches)
+
+	var diags diag.Diagnostics
+	if _, err := c.cache.teams.get(false, fetch); err != nil {
+		t.Fatal(err)
+	}
+	c.invalidateOnError(&diags)
+	if _, err := c.cache.teams.get(false, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected the cache to be kept without errors, got %d fetches", n)
+	}
+
+	diags.AddError("Error", "failed")
+	c.invalidateOnError(&diags)
+	if _, err := c.cache.teams.get(false, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected the cache to be dropped on errors, got %d fetches", n)
+	}
+
+	// Mappings of groups not known by name can't be cached
+	c.cacheOIDCMapping(uuid.New(), dtrack.OIDCGroup{UUID: uuid.New()}, dtrack.OIDCMapping{UUID: uuid.New()})
+	if _, err := c.cache.teams.get(false, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 3 {
+		t.Errorf("expected the cache to be dropped for unknown groups, got %d fetches", n)
+	}
+}
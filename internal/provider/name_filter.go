@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// nameFilter matches names by the optional name_regex and name_prefix arguments of list data sources.
+type nameFilter struct {
+	regex  *regexp.Regexp
+	prefix string
+}
+
+// newNameFilter returns a filter for the configured arguments. Null arguments match all names.
+func newNameFilter(regex types.String, prefix types.String) (nameFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	f := nameFilter{prefix: prefix.ValueString()}
+	if regex.IsNull() || regex.IsUnknown() {
+		return f, diags
+	}
+	re, err := regexp.Compile(regex.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q of attribute name_regex is not a valid regular expression: %v", regex.ValueString(), err),
+		)
+		return f, diags
+	}
+	f.regex = re
+	return f, diags
+}
+
+// matches returns if the name has the prefix and matches the regex.
+func (f nameFilter) matches(name string) bool {
+	if !strings.HasPrefix(name, f.prefix) {
+		return false
+	}
+	return f.regex == nil || f.regex.MatchString(name)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &oidcGroupDataSource{}
+	_ datasource.DataSourceWithConfigure = &oidcGroupDataSource{}
+)
+
+func NewOidcGroupDataSource() datasource.DataSource {
+	return &oidcGroupDataSource{}
+}
+
+func (d *oidcGroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *oidcGroupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_group"
+}
+
+// Schema defines the schema for the data source.
+func (d *oidcGroupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an OIDC group by name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the OIDC group.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the OIDC group.",
+				Required:    true,
+			},
+			"teams": schema.SetAttribute{
+				Description: "Names of the teams the OIDC group is mapped to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"team_ids": schema.SetAttribute{
+				Description: "UUIDs of the teams the OIDC group is mapped to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *oidcGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config oidcGroupModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.allOIDCGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read DependencyTrack OIDC Groups",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, group := range groups {
+		if group.Name != config.Name.ValueString() {
+			continue
+		}
+
+		teams, err := d.client.groupTeams(ctx, group)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read DependencyTrack OIDC Group Teams",
+				err.Error(),
+			)
+			return
+		}
+
+		// Set state
+		state := newOIDCGroupModel(group, teams)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("name"),
+		"Unable to Read DependencyTrack OIDC Group",
+		fmt.Sprintf("Could not find OIDC Group with name %q", config.Name.ValueString()),
+	)
+}
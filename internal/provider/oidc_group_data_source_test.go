@@ -0,0 +1,50 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOidcGroupDataSource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	backendID := mock.addTeam("dev-backend")
+	frontendID := mock.addTeam("dev-frontend")
+	mock.addOIDCGroup("developers")
+	mock.addOIDCGroup("operators")
+	mock.addOIDCMapping("developers", backendID)
+	mock.addOIDCMapping("developers", frontendID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: cfg + `data "dependencytrack_oidc_group" "test" { name = "developers" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.dependencytrack_oidc_group.test", "id"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_group.test", "teams.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_group.test", "teams.*", "dev-backend"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_group.test", "teams.*", "dev-frontend"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_group.test", "team_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_group.test", "team_ids.*", backendID.String()),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_group.test", "team_ids.*", frontendID.String()),
+				),
+			},
+			// Unmapped group testing
+			{
+				Config: cfg + `data "dependencytrack_oidc_group" "test" { name = "operators" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_group.test", "teams.#", "0"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_group.test", "team_ids.#", "0"),
+				),
+			},
+			// Not found testing
+			{
+				Config:      cfg + `data "dependencytrack_oidc_group" "test" { name = "unknown" }`,
+				ExpectError: regexp.MustCompile(`Could not find OIDC Group with name "unknown"`),
+			},
+		},
+	})
+}
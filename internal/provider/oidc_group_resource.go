@@ -2,24 +2,31 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sync"
 
 	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &oidcGroupResource{}
-	_ resource.ResourceWithConfigure   = &oidcGroupResource{}
-	_ resource.ResourceWithImportState = &oidcGroupResource{}
+	_ resource.Resource                 = &oidcGroupResource{}
+	_ resource.ResourceWithConfigure    = &oidcGroupResource{}
+	_ resource.ResourceWithImportState  = &oidcGroupResource{}
+	_ resource.ResourceWithModifyPlan   = &oidcGroupResource{}
+	_ resource.ResourceWithUpgradeState = &oidcGroupResource{}
 )
 
 // NewOidcGroupResource is a helper function to simplify the provider implementation.
@@ -33,12 +40,12 @@ func (r *oidcGroupResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -55,6 +62,7 @@ func (r *oidcGroupResource) Metadata(_ context.Context, req resource.MetadataReq
 // Schema defines the schema for the resource.
 func (r *oidcGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -62,34 +70,141 @@ func (r *oidcGroupResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			//"last_updated": schema.StringAttribute{
-			//	Computed: true,
-			//},
 			"name": schema.StringAttribute{
 				Required: true,
 			},
 			"teams": schema.SetAttribute{
+				Description: "Names of the teams the OIDC group is mapped to, mappings to other teams are removed. " +
+					"If not set, the mappings are not managed by this resource, e.g. to manage them with dependencytrack_oidc_group_team_mapping resources. " +
+					"Both must not be used for the same group.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing OIDC group with the same name on creation instead of failing. " +
+					"Defaults to the adopt_existing setting of the provider.",
+				Optional: true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the OIDC group in DependencyTrack including its team mappings, " +
+					"changes when the group or its mappings are changed outside of Terraform.",
+				Computed: true,
+			},
 		},
 	}
 }
 
+// UpgradeState upgrades the state of older schema versions.
+func (r *oidcGroupResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 had no fingerprint, it is set by the next refresh
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":             schema.StringAttribute{Computed: true},
+					"name":           schema.StringAttribute{Required: true},
+					"teams":          schema.SetAttribute{Optional: true, ElementType: types.StringType},
+					"adopt_existing": schema.BoolAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior oidcGroupResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, oidcGroupResourceModel{
+					ID:            prior.ID,
+					Name:          prior.Name,
+					Teams:         prior.Teams,
+					AdoptExisting: prior.AdoptExisting,
+					Fingerprint:   types.StringNull(),
+				})...)
+			},
+		},
+	}
+}
+
+// oidcGroupManagedTeamsKey is the private state key of the names of the teams mapped by the resource.
+const oidcGroupManagedTeamsKey = "managed_teams"
+
+// ModifyPlan verifies the API token has the permissions required to manage OIDC groups
+// and warns about mappings to be removed, that were not created by the resource.
+func (r *oidcGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_oidc_group", dtrack.PermissionAccessManagement)...)
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state oidcGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Teams.IsNull() || plan.Teams.IsUnknown() || state.Teams.IsNull() {
+		return
+	}
+
+	// The managed teams are unknown for imported groups and groups created by older provider versions
+	b, diags := req.Private.GetKey(ctx, oidcGroupManagedTeamsKey)
+	resp.Diagnostics.Append(diags...)
+	if b == nil {
+		return
+	}
+	var managed []string
+	if err := json.Unmarshal(b, &managed); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading private state",
+			"Could not read the teams managed by the OIDC group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	planned := setStrings(plan.Teams)
+	for _, name := range sortedStrings(setStrings(state.Teams)) {
+		if slices.Contains(planned, name) || slices.Contains(managed, name) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("teams"),
+			"Removing unmanaged OIDC Group - Team Mapping",
+			fmt.Sprintf("The mapping to team %q was not created by this resource and is removed, as it is not listed in teams. "+
+				"If the mapping is managed by a dependencytrack_oidc_group_team_mapping resource, remove the teams attribute, "+
+				"as both resources must not manage the mappings of the same group.", name),
+		)
+	}
+}
+
+// setManagedTeams records the names of the teams mapped by the resource in the private state, see ModifyPlan.
+func setManagedTeams(ctx context.Context, private interface {
+	SetKey(context.Context, string, []byte) diag.Diagnostics
+}, teams types.Set,
+) diag.Diagnostics {
+	if teams.IsNull() {
+		return private.SetKey(ctx, oidcGroupManagedTeamsKey, nil)
+	}
+	b, err := json.Marshal(sortedStrings(setStrings(teams)))
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing private state", "Could not write the teams managed by the OIDC group, unexpected error: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, oidcGroupManagedTeamsKey, b)
+}
+
 // Create creates the oidcGroup and sets the initial Terraform state.
 func (r *oidcGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
-	var plan oidcGroupModel
+	var plan oidcGroupResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
 	// Get refreshed order value from DependencyTrack
-	allGroups, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.OIDCGroup], error) {
-		return r.client.OIDC.GetAllGroups(ctx, po)
-	})
+	allGroups, err := r.client.allOIDCGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting all OIDC Groups",
@@ -98,39 +213,27 @@ func (r *oidcGroupResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	for _, existing := range allGroups {
-		if existing.Name == plan.Name.ValueString() {
-			resp.Diagnostics.AddError(
-				"Error creating team",
-				fmt.Sprintf("A IDC Groups with name %q exists already with UUID %q",
-					plan.Name.ValueString(), existing.UUID.String()),
-			)
-			return
+	var existing *dtrack.OIDCGroup
+	for i := range allGroups {
+		if allGroups[i].Name == plan.Name.ValueString() {
+			existing = &allGroups[i]
 		}
 	}
-
-	oidcGroup := dtrack.OIDCGroup{
-		Name: plan.Name.ValueString(),
-	}
-
-	// Create new oidcGroup
-	result, err := r.client.OIDC.CreateGroup(ctx, oidcGroup.Name)
-	if err != nil {
+	if existing != nil && !r.client.adopt(plan.AdoptExisting) {
 		resp.Diagnostics.AddError(
-			"Error creating oidcGroup",
-			"Could not create oidcGroup, unexpected error: "+err.Error(),
+			"Error creating team",
+			fmt.Sprintf("A IDC Groups with name %q exists already with UUID %q. Set adopt_existing to manage the existing OIDC group.",
+				plan.Name.ValueString(), existing.UUID.String()),
 		)
 		return
 	}
 
-	// Map response body to schema and populate Computed attribute values
-	plan.ID = types.StringValue(result.UUID.String())
-	// plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	oidcGroup := dtrack.OIDCGroup{
+		Name: plan.Name.ValueString(),
+	}
 
-	// Get refreshed order value from DependencyTrack
-	teams, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-		return r.client.Team.GetAll(ctx, po)
-	})
+	// Resolve all teams before anything is written
+	teams, err := r.client.allTeams(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting Teams",
@@ -145,18 +248,45 @@ func (r *oidcGroupResource) Create(ctx context.Context, req resource.CreateReque
 		teamMap[t.Name] = t
 	}
 
+	var mappedTeams []dtrack.Team
 	for _, t := range plan.Teams.Elements() {
 		name := valueString(t)
 		team, ok := teamMap[name]
 		if !ok {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.AddAttributeError(
+				path.Root("teams"),
 				"Error mapping Team",
 				fmt.Sprintf("Could not create OIDC Group - Team Mapping, team %q not found", name),
 			)
-			return
+			continue
 		}
+		mappedTeams = append(mappedTeams, team)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if existing != nil {
+		r.adoptExisting(ctx, *existing, mappedTeams, plan, resp)
+		return
+	}
+
+	// Create new oidcGroup
+	result, err := r.client.OIDC.CreateGroup(ctx, oidcGroup.Name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating oidcGroup",
+			"Could not create oidcGroup, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	r.client.cacheOIDCGroup(result)
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(result.UUID.String())
 
-		_, err = r.client.OIDC.AddTeamMapping(ctx, dtrack.OIDCMappingRequest{
+	for _, team := range mappedTeams {
+		mapping, err := r.client.OIDC.AddTeamMapping(ctx, dtrack.OIDCMappingRequest{
 			Group: result.UUID,
 			Team:  team.UUID,
 		})
@@ -165,16 +295,190 @@ func (r *oidcGroupResource) Create(ctx context.Context, req resource.CreateReque
 				"Error creating OIDC Group - Team Mapping",
 				"Could not create OIDC Group - Team Mapping, unexpected error: "+err.Error(),
 			)
+			r.rollbackCreate(ctx, result, plan, resp)
 			return
 		}
+		r.client.cacheOIDCMapping(team.UUID, result, mapping)
 	}
 
 	// Set state to fully populated data
+	plan.Fingerprint = oidcGroupFingerprint(result, mappedTeams)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	resp.Diagnostics.Append(setManagedTeams(ctx, resp.Private, plan.Teams)...)
+}
+
+// adoptExisting takes over an existing OIDC group with the planned name and reconciles its team mappings to the plan.
+// Other than a created group, an adopted group is not deleted if reconciling fails.
+func (r *oidcGroupResource) adoptExisting(ctx context.Context, group dtrack.OIDCGroup, mappedTeams []dtrack.Team,
+	plan oidcGroupResourceModel, resp *resource.CreateResponse,
+) {
+	tflog.Info(ctx, "Adopting existing OIDC group", map[string]any{"id": group.UUID.String(), "name": group.Name})
+
+	mapped, err := r.client.groupTeams(ctx, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Teams of Group",
+			fmt.Sprintf("Could not get Teams of Group %v, unexpected error: %v", group.UUID, err),
+		)
 		return
 	}
+
+	// Without teams, the mappings are not managed by this resource, e.g. if dependencytrack_oidc_group_team_mapping is used.
+	if !plan.Teams.IsNull() {
+		resp.Diagnostics.Append(r.client.syncTeamMappings(ctx, path.Root("teams"), group, mappedTeams, mapped)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		mapped = mappedTeams
+	}
+
+	plan.ID = types.StringValue(group.UUID.String())
+	plan.Fingerprint = oidcGroupFingerprint(group, mapped)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setManagedTeams(ctx, resp.Private, plan.Teams)...)
+}
+
+// syncTeamMappings maps the group to the planned teams and removes the mappings to all other teams.
+// The mappings to remove are looked up in the details of the teams currently mapped to the group.
+// Mappings that can't be found or removed are reported as errors for the attribute at p.
+func (c *apiClient) syncTeamMappings(ctx context.Context, p path.Path, group dtrack.OIDCGroup, planned []dtrack.Team, mapped []dtrack.Team) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current := make(map[uuid.UUID]dtrack.Team)
+	for _, t := range mapped {
+		current[t.UUID] = t
+	}
+
+	for _, t := range planned {
+		if _, ok := current[t.UUID]; ok {
+			delete(current, t.UUID)
+			continue
+		}
+		mapping, err := c.OIDC.AddTeamMapping(ctx, dtrack.OIDCMappingRequest{Group: group.UUID, Team: t.UUID})
+		if err != nil {
+			diags.AddAttributeError(
+				p,
+				"Error creating OIDC Group - Team Mapping",
+				fmt.Sprintf("Could not map OIDC Group to Team %q, unexpected error: %v", t.Name, err),
+			)
+			continue
+		}
+		c.cacheOIDCMapping(t.UUID, group, mapping)
+	}
+
+	for _, t := range current {
+		// The team details include the mapped OIDC groups, which are missing in team lists of some versions
+		details, err := c.getTeamDetails(ctx, t.UUID)
+		if err != nil {
+			diags.AddAttributeError(
+				p,
+				"Error removing OIDC Group - Team Mapping",
+				fmt.Sprintf("Could not get Team %q, unexpected error: %v", t.Name, err),
+			)
+			continue
+		}
+		mapping, ok := findOIDCMapping([]dtrack.Team{details.Team}, group.UUID, t.UUID)
+		if !ok {
+			diags.AddAttributeError(
+				p,
+				"Error removing OIDC Group - Team Mapping",
+				fmt.Sprintf("Could not remove mapping of OIDC Group to Team %q, the mapping was not found", t.Name),
+			)
+			continue
+		}
+		if err := c.OIDC.RemoveTeamMapping(ctx, mapping.UUID); err != nil {
+			diags.AddAttributeError(
+				p,
+				"Error removing OIDC Group - Team Mapping",
+				fmt.Sprintf("Could not remove mapping of OIDC Group to Team %q, unexpected error: %v", t.Name, err),
+			)
+			continue
+		}
+		c.uncacheOIDCMapping(mapping.UUID)
+	}
+	return diags
+}
+
+// getOIDCGroup returns the OIDC group with the UUID.
+// As there is no endpoint for a single OIDC group, a missing group wraps errNotFound.
+func (c *apiClient) getOIDCGroup(ctx context.Context, id uuid.UUID) (dtrack.OIDCGroup, error) {
+	groups, err := c.allOIDCGroups(ctx)
+	if err != nil {
+		return dtrack.OIDCGroup{}, err
+	}
+	for _, g := range groups {
+		if g.UUID == id {
+			return g, nil
+		}
+	}
+	return dtrack.OIDCGroup{}, fmt.Errorf("OIDC group %s: %w", id, errNotFound)
+}
+
+// groupTeams returns the teams the OIDC group is mapped to, including their mapped OIDC groups.
+func (c *apiClient) groupTeams(ctx context.Context, group dtrack.OIDCGroup) ([]dtrack.Team, error) {
+	return dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
+		return c.OIDC.GetAllTeamsOf(ctx, group, po)
+	})
+}
+
+// teamsOfGroups returns the teams each of the OIDC groups is mapped to, requesting the teams of up to parallelism groups at the same time.
+// Like groupTeams, the teams are requested per group, as team listings of some DependencyTrack versions omit the mapped OIDC groups.
+func (c *apiClient) teamsOfGroups(ctx context.Context, groups []dtrack.OIDCGroup, parallelism int64) (map[uuid.UUID][]dtrack.Team, diag.Diagnostics) {
+	var mu sync.Mutex
+	teams := make(map[uuid.UUID][]dtrack.Team, len(groups))
+	tasks := make([]func() diag.Diagnostics, 0, len(groups))
+	for _, group := range groups {
+		tasks = append(tasks, func() diag.Diagnostics {
+			var diags diag.Diagnostics
+			mapped, err := c.groupTeams(ctx, group)
+			if err != nil {
+				diags.AddError(
+					"Unable to Read DependencyTrack OIDC Group Teams",
+					fmt.Sprintf("Could not read the teams of OIDC Group %q: %v", group.Name, err),
+				)
+				return diags
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			teams[group.UUID] = mapped
+			return diags
+		})
+	}
+	return teams, runConcurrently(parallelism, tasks)
+}
+
+// oidcGroupFingerprint returns the fingerprint of the OIDC group in DependencyTrack including the teams it is mapped to.
+// The teams are part of it even if the mappings are not managed by the resource.
+func oidcGroupFingerprint(group dtrack.OIDCGroup, teams []dtrack.Team) types.String {
+	return fingerprint(group.UUID.String(), group.Name, sortedStrings(setStrings(oidcGroupTeamNames(teams))))
+}
+
+// oidcGroupTeamNames returns the names of the teams.
+func oidcGroupTeamNames(teams []dtrack.Team) types.Set {
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	return stringSet(names)
+}
+
+// rollbackCreate deletes a partially created OIDC group. If the group can not be deleted, it is saved to the state,
+// so Terraform marks it as tainted and replaces it on the next apply.
+func (r *oidcGroupResource) rollbackCreate(ctx context.Context, group dtrack.OIDCGroup, plan oidcGroupResourceModel, resp *resource.CreateResponse) {
+	err := r.client.OIDC.DeleteGroup(ctx, group.UUID)
+	if err == nil {
+		tflog.Info(ctx, "Rolled back partially created OIDC group", map[string]any{"id": group.UUID.String()})
+		r.client.uncacheOIDCGroup(group.UUID)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Error rolling back OIDC group creation",
+		fmt.Sprintf("Could not delete partially created OIDC group %q, it is kept as tainted resource and will be replaced on the next apply. "+
+			"Unexpected error: %v", group.UUID, err),
+	)
+	plan.Fingerprint = oidcGroupFingerprint(group, nil)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func valueString(t attr.Value) string {
@@ -187,17 +491,26 @@ func valueString(t attr.Value) string {
 // Read refreshes the Terraform state with the latest data.
 func (r *oidcGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
-	var state oidcGroupModel
+	var state oidcGroupResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get refreshed order value from DependencyTrack
-	groups, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.OIDCGroup], error) {
-		return r.client.OIDC.GetAllGroups(ctx, po)
-	})
+	group, err := r.client.getOIDCGroup(ctx, id)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC group not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading DependencyTrack OIDC Groups",
@@ -206,25 +519,16 @@ func (r *oidcGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	var group *dtrack.OIDCGroup
-	for i := range groups {
-		r := groups[i]
-		if state.ID.ValueString() == r.UUID.String() {
-			group = &r
-		}
-	}
-	if group == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Overwrite items with refreshed state
 	state.ID = types.StringValue(group.UUID.String())
 	state.Name = types.StringValue(group.Name)
 
-	teams, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-		return r.client.OIDC.GetAllTeamsOf(ctx, *group, po)
-	})
+	teams, err := r.client.groupTeams(ctx, group)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC group not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read DependencyTrack Teams",
@@ -233,11 +537,11 @@ func (r *oidcGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	var teamNames []attr.Value
-	for _, team := range teams {
-		teamNames = append(teamNames, types.StringValue(team.Name))
+	// Without teams, the mappings are not managed by this resource, e.g. if dependencytrack_oidc_group_team_mapping is used.
+	if !state.Teams.IsNull() {
+		state.Teams = oidcGroupTeamNames(teams)
 	}
-	state.Teams = types.SetValueMust(types.StringType, teamNames)
+	state.Fingerprint = oidcGroupFingerprint(group, teams)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -250,115 +554,99 @@ func (r *oidcGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 // Update updates the oidcGroup and sets the updated Terraform state on success.
 func (r *oidcGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan
-	var plan oidcGroupModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	var plan, state oidcGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	oidcGroup := dtrack.OIDCGroup{
-		UUID: uuid.MustParse(plan.ID.ValueString()),
-		Name: plan.Name.ValueString(),
-	}
+	defer r.client.invalidateOnError(&resp.Diagnostics)
 
-	// Update existing oidcGroup
-	_, err := r.client.OIDC.UpdateGroup(ctx, oidcGroup)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating oidcGroup",
-			"Could not update oidcGroup, unexpected error: "+err.Error(),
-		)
+	id, diags := parseUUID(path.Root("id"), plan.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get refreshed order value from DependencyTrack
-	groupTeams, err := fetchAllMappedByUI(
-		func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-			return r.client.OIDC.GetAllTeamsOf(ctx, oidcGroup, po)
-		},
-		func(it dtrack.Team) string {
-			return it.Name
-		},
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting Teams of Group",
-			fmt.Sprintf("Could not get Teams of Group %v, unexpected error: %v", oidcGroup.UUID, err),
-		)
-		return
+	oidcGroup := dtrack.OIDCGroup{
+		UUID: id,
+		Name: plan.Name.ValueString(),
 	}
 
-	allTeams, err := fetchAllMappedByUI(
-		func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-			return r.client.Team.GetAll(ctx, po)
-		},
-		func(it dtrack.Team) string {
+	// Resolve all teams before anything is written
+	var planned []dtrack.Team
+	if !plan.Teams.IsNull() {
+		teams, err := r.client.allTeams(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting Teams",
+				"Could not get Teams, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		teamMap := mapByID(teams, func(it dtrack.Team) string {
 			return it.Name
-		},
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting Teams ",
-			fmt.Sprintf("Could not get Teams, unexpected error: %v", err),
-		)
-		return
-	}
-
-	for _, t := range plan.Teams.Elements() {
-
-		name := valueString(t)
-		_, ok := groupTeams[name]
-		if !ok {
-			team, ok := allTeams[name]
+		})
+		for _, name := range setStrings(plan.Teams) {
+			team, ok := teamMap[name]
 			if !ok {
-				resp.Diagnostics.AddError(
-					"Error finding Team",
-					fmt.Sprintf("Could not find Team %q", name),
-				)
-				return
-			}
-
-			_, err = r.client.OIDC.AddTeamMapping(ctx, dtrack.OIDCMappingRequest{
-				Group: oidcGroup.UUID,
-				Team:  team.UUID,
-			})
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error creating OIDC Group - Team Mapping",
-					fmt.Sprintf("Could not create OIDC Group (%s) - Team Mapping (%s/%s), unexpected error: %v", oidcGroup.UUID, name, team.UUID, err),
+				resp.Diagnostics.AddAttributeError(
+					path.Root("teams"),
+					"Error mapping Team",
+					fmt.Sprintf("Could not create OIDC Group - Team Mapping, team %q not found", name),
 				)
-				return
+				continue
 			}
-		} else {
-			delete(groupTeams, name)
+			planned = append(planned, team)
+		}
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
-	for teamName := range groupTeams {
-		team, ok := allTeams[teamName]
-		if !ok {
+	// Update existing oidcGroup
+	if !plan.Name.Equal(state.Name) {
+		updated, err := r.client.OIDC.UpdateGroup(ctx, oidcGroup)
+		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error finding Team",
-				fmt.Sprintf("Could not find Team %q", teamName),
+				"Error updating oidcGroup",
+				"Could not update oidcGroup, unexpected error: "+err.Error(),
 			)
 			return
 		}
-		for _, m := range team.MappedOIDCGroups {
-			if m.Group.UUID.String() == plan.ID.ValueString() {
-				err = r.client.OIDC.RemoveTeamMapping(ctx, m.UUID)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error creating OIDC Group - Team Mapping",
-						"Could not create OIDC Group - Team Mapping, unexpected error: "+err.Error(),
-					)
-					return
-				}
-			}
+		oidcGroup = updated
+		r.client.cacheOIDCGroup(updated)
+	}
+
+	// Without teams, the mappings are not managed by this resource, e.g. if dependencytrack_oidc_group_team_mapping is used.
+	if !plan.Teams.IsNull() {
+		mapped, err := r.client.groupTeams(ctx, oidcGroup)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting Teams of Group",
+				fmt.Sprintf("Could not get Teams of Group %v, unexpected error: %v", oidcGroup.UUID, err),
+			)
+			return
 		}
+		resp.Diagnostics.Append(r.client.syncTeamMappings(ctx, path.Root("teams"), oidcGroup, planned, mapped)...)
+	}
+
+	// Read back the mappings, so mappings that failed are planned again
+	mapped, err := r.client.groupTeams(ctx, oidcGroup)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Teams of Group",
+			fmt.Sprintf("Could not get Teams of Group %v, unexpected error: %v", oidcGroup.UUID, err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setManagedTeams(ctx, resp.Private, plan.Teams)...)
+	if !plan.Teams.IsNull() {
+		plan.Teams = oidcGroupTeamNames(mapped)
 	}
+	plan.Fingerprint = oidcGroupFingerprint(oidcGroup, mapped)
 
-	// plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -369,15 +657,28 @@ func (r *oidcGroupResource) Update(ctx context.Context, req resource.UpdateReque
 // Delete deletes the oidcGroup and removes the Terraform state on success.
 func (r *oidcGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
-	var state oidcGroupModel
+	var state oidcGroupResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
 	// Delete existing order
-	err := r.client.OIDC.DeleteGroup(ctx, uuid.MustParse(state.ID.ValueString()))
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.OIDC.DeleteGroup(ctx, id)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC group already deleted", map[string]any{"id": state.ID.ValueString()})
+		r.client.uncacheOIDCGroup(id)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting DependencyTrack Repository",
@@ -385,9 +686,36 @@ func (r *oidcGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 		)
 		return
 	}
+	r.client.uncacheOIDCGroup(id)
 }
 
+// ImportState imports an OIDC group by UUID or by name, e.g. "name:Developers".
 func (r *oidcGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Read only refreshes the teams if they are known, so the mappings of imported groups are imported as well.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("teams"), types.SetValueMust(types.StringType, nil))...)
+
+	name, ok := parseNameImportID(req.ID)
+	if !ok {
+		importStateUUID(ctx, req, resp, `an OIDC group UUID or name like "name:Developers"`)
+		return
+	}
+
+	groups, err := r.client.allOIDCGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting all OIDC Groups",
+			"Could not get OIDC Groups, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	for _, g := range groups {
+		if g.Name == name {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), g.UUID.String())...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error importing OIDC group",
+		fmt.Sprintf("Could not find an OIDC group with name %q", name),
+	)
 }
@@ -0,0 +1,262 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestOidcGroupResource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	mock.addTeam("Operators")
+	expectWrites := func(expected ...string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if writes := mock.takeWrites(); !slices.Equal(writes, expected) {
+				return fmt.Errorf("expected writes %v, got %v", expected, writes)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name  = "developers"
+  teams = ["Developers"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("dependencytrack_oidc_group.test", "id"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "name", "developers"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_group.test", "teams.*", "Developers"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "dependencytrack_oidc_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Add mapping testing
+			{
+				PreConfig: func() { mock.takeWrites() },
+				Config: cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name  = "developers"
+  teams = ["Developers", "Operators"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "teams.#", "2"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_group.test", "teams.*", "Operators"),
+					expectWrites("PUT /api/v1/oidc/mapping"),
+				),
+			},
+			// Remove mapping testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name  = "developers"
+  teams = ["Operators"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_group.test", "teams.*", "Operators"),
+					expectWrites("DELETE /api/v1/oidc/mapping/{mapping}"),
+				),
+			},
+			// Rename testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name  = "operators"
+  teams = ["Operators"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "name", "operators"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "teams.#", "1"),
+					expectWrites("POST /api/v1/oidc/group"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestOidcGroupResourceMappingsNotInTeamList(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name  = "developers"
+  teams = ["Developers"]
+}
+`,
+			},
+			// Mappings missing in the team list are looked up in the team details
+			{
+				PreConfig: func() { mock.setOmitOIDCMappings(true) },
+				Config: cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name  = "developers"
+  teams = []
+}
+`,
+				Check: resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "teams.#", "0"),
+			},
+		},
+	})
+}
+
+func TestOidcGroupResourceFingerprint(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers")
+	config := cfg + `
+resource "dependencytrack_oidc_group" "test" {
+  name = "developers"
+}
+`
+	fingerprintChanged := statecheck.CompareValue(compare.ValuesDiffer())
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_oidc_group.test", tfjsonpath.New("fingerprint")),
+				},
+			},
+			// Mappings are not managed without teams, but change the fingerprint
+			{
+				PreConfig: func() { mock.addOIDCMapping("developers", teamID) },
+				Config:    config,
+				Check:     resource.TestCheckNoResourceAttr("dependencytrack_oidc_group.test", "teams"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_oidc_group.test", tfjsonpath.New("fingerprint")),
+				},
+			},
+		},
+	})
+}
+
+func TestOidcGroupResourceUnmanagedMappingWarning(t *testing.T) {
+	server, _, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	mock.addTeam("Developers")
+	adminsID := mock.addTeam("Administrators")
+	srv := configuredProviderServer(t, server.URL)
+	ctx := context.Background()
+
+	schemas, err := srv.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemas.ResourceSchemas["dependencytrack_oidc_group"].ValueType().(tftypes.Object)
+	// value returns the resource with the teams, other attributes are taken from prior if set
+	value := func(prior *tfprotov6.DynamicValue, teams ...string) *tfprotov6.DynamicValue {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range typ.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		if prior != nil {
+			v, err := prior.Unmarshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := v.As(&values); err != nil {
+				t.Fatal(err)
+			}
+		}
+		elems := make([]tftypes.Value, 0, len(teams))
+		for _, team := range teams {
+			elems = append(elems, tftypes.NewValue(tftypes.String, team))
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "developers")
+		values["teams"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems)
+		dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dv
+	}
+	null, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := value(nil, "Developers")
+	plan, err := srv.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "dependencytrack_oidc_group",
+		PriorState:       &null,
+		ProposedNewState: config,
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "plan", plan.Diagnostics)
+	applied, err := srv.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "dependencytrack_oidc_group",
+		PriorState:     &null,
+		PlannedState:   plan.PlannedState,
+		Config:         config,
+		PlannedPrivate: plan.PlannedPrivate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "apply", applied.Diagnostics)
+
+	// A mapping managed elsewhere, e.g. by a dependencytrack_oidc_group_team_mapping resource
+	mock.addOIDCMapping("developers", adminsID)
+	read, err := srv.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "dependencytrack_oidc_group",
+		CurrentState: applied.NewState,
+		Private:      applied.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "read", read.Diagnostics)
+
+	plan, err = srv.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "dependencytrack_oidc_group",
+		PriorState:       read.NewState,
+		ProposedNewState: value(read.NewState),
+		Config:           value(nil),
+		PriorPrivate:     read.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "plan", plan.Diagnostics)
+	// Removing the mapping created by the resource is not reported
+	if len(plan.Diagnostics) != 1 || !strings.Contains(plan.Diagnostics[0].Detail, `team "Administrators"`) {
+		t.Fatalf("expected a warning about the unmanaged mapping to Administrators only, got %v", plan.Diagnostics)
+	}
+}
@@ -0,0 +1,394 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &oidcGroupSetResource{}
+	_ resource.ResourceWithConfigure  = &oidcGroupSetResource{}
+	_ resource.ResourceWithModifyPlan = &oidcGroupSetResource{}
+)
+
+// NewOidcGroupSetResource is a helper function to simplify the provider implementation.
+func NewOidcGroupSetResource() resource.Resource {
+	return &oidcGroupSetResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *oidcGroupSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the OIDC group set type name.
+func (r *oidcGroupSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_group_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *oidcGroupSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 0,
+		Description: "Manages many OIDC groups and their team mappings in a single resource. " +
+			"Existing OIDC groups with a listed name are managed by this resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"groups": schema.MapAttribute{
+				Description: "Names of the teams each OIDC group is mapped to by OIDC group name. OIDC groups removed from the map are deleted.",
+				Required:    true,
+				ElementType: types.SetType{ElemType: types.StringType},
+			},
+			"prune_prefix": schema.StringAttribute{
+				Description: "If set, OIDC groups with a name starting with this prefix are deleted if they are not listed in groups.",
+				Optional:    true,
+			},
+			"parallelism": schema.Int64Attribute{
+				Description: "Maximum number of OIDC groups synchronized concurrently. Defaults to 4.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(4),
+			},
+			"group_ids": schema.MapAttribute{
+				Description: "UUIDs of the OIDC groups by OIDC group name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the OIDC groups of the set and their team mappings in DependencyTrack, " +
+					"changes when they are changed outside of Terraform.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan verifies the API token has the permissions required to manage OIDC groups.
+func (r *oidcGroupSetResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_oidc_group_set", dtrack.PermissionAccessManagement)...)
+}
+
+// Create creates the OIDC groups and sets the initial Terraform state.
+func (r *oidcGroupSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oidcGroupSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing is saved to the state if the groups can't be resolved, as nothing was written yet.
+	tasks, diags := r.syncTasks(ctx, plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.NewString())
+	resp.Diagnostics.Append(r.run(ctx, plan, tasks)...)
+	r.setState(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *oidcGroupSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oidcGroupSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setState(ctx, state, &resp.State, &resp.Diagnostics)
+}
+
+// Update synchronizes the OIDC groups and sets the updated Terraform state.
+func (r *oidcGroupSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state oidcGroupSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupIDs := make(map[string]string)
+	resp.Diagnostics.Append(state.GroupIDs.ElementsAs(ctx, &groupIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tasks, diags := r.syncTasks(ctx, plan, groupIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.run(ctx, plan, tasks)...)
+	r.setState(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Delete deletes all OIDC groups of the set.
+func (r *oidcGroupSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oidcGroupSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
+	groupIDs := make(map[string]string)
+	resp.Diagnostics.Append(state.GroupIDs.ElementsAs(ctx, &groupIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tasks []func() diag.Diagnostics
+	for _, name := range slices.Sorted(maps.Keys(groupIDs)) {
+		tasks = append(tasks, func() diag.Diagnostics {
+			return r.deleteGroup(ctx, path.Root("group_ids").AtMapKey(name), name, groupIDs[name])
+		})
+	}
+	resp.Diagnostics.Append(runConcurrently(state.Parallelism.ValueInt64(), tasks)...)
+}
+
+// syncTasks computes the difference between the planned and the existing OIDC groups and returns a task per group to apply it.
+// Groups of the prior group IDs no longer planned are deleted, as are unlisted groups matching the prune prefix.
+// All teams are resolved before, so unknown teams are reported before anything is written.
+func (r *oidcGroupSetResource) syncTasks(ctx context.Context, plan oidcGroupSetResourceModel, priorIDs map[string]string,
+) ([]func() diag.Diagnostics, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	planned := make(map[string][]string)
+	diags.Append(plan.Groups.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	groups, err := r.client.allOIDCGroups(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error getting all OIDC Groups",
+			"Could not get OIDC Groups, unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+	teams, err := r.client.allTeams(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error getting Teams",
+			"Could not get Teams, unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+	groupsByName := mapByID(groups, func(it dtrack.OIDCGroup) string {
+		return it.Name
+	})
+	teamsByName := mapByID(teams, func(it dtrack.Team) string {
+		return it.Name
+	})
+
+	var tasks []func() diag.Diagnostics
+	for _, name := range slices.Sorted(maps.Keys(planned)) {
+		p := path.Root("groups").AtMapKey(name)
+		var mapped []dtrack.Team
+		for _, teamName := range planned[name] {
+			team, ok := teamsByName[teamName]
+			if !ok {
+				diags.AddAttributeError(
+					p,
+					"Error mapping Team",
+					fmt.Sprintf("Could not map OIDC Group %q to Team %q, team not found", name, teamName),
+				)
+				continue
+			}
+			mapped = append(mapped, team)
+		}
+
+		group, exists := groupsByName[name]
+		tasks = append(tasks, func() diag.Diagnostics {
+			var diags diag.Diagnostics
+			var current []dtrack.Team
+			if exists {
+				var err error
+				if current, err = r.client.groupTeams(ctx, group); err != nil {
+					diags.AddAttributeError(
+						p,
+						"Error getting OIDC Group Teams",
+						fmt.Sprintf("Could not get the teams of OIDC Group %q, unexpected error: %v", name, err),
+					)
+					return diags
+				}
+			} else {
+				created, err := r.client.OIDC.CreateGroup(ctx, name)
+				if err != nil {
+					diags.AddAttributeError(
+						p,
+						"Error creating OIDC Group",
+						fmt.Sprintf("Could not create OIDC Group %q, unexpected error: %v", name, err),
+					)
+					return diags
+				}
+				group = created
+				r.client.cacheOIDCGroup(created)
+			}
+			return r.client.syncTeamMappings(ctx, p, group, mapped, current)
+		})
+	}
+
+	removed := make(map[string]bool)
+	for _, name := range slices.Sorted(maps.Keys(priorIDs)) {
+		if _, ok := planned[name]; ok {
+			continue
+		}
+		removed[priorIDs[name]] = true
+		tasks = append(tasks, func() diag.Diagnostics {
+			return r.deleteGroup(ctx, path.Root("groups"), name, priorIDs[name])
+		})
+	}
+
+	if !plan.PrunePrefix.IsNull() {
+		for _, group := range groups {
+			if _, ok := planned[group.Name]; ok || removed[group.UUID.String()] || !strings.HasPrefix(group.Name, plan.PrunePrefix.ValueString()) {
+				continue
+			}
+			tasks = append(tasks, func() diag.Diagnostics {
+				return r.deleteGroup(ctx, path.Root("prune_prefix"), group.Name, group.UUID.String())
+			})
+		}
+	}
+	return tasks, diags
+}
+
+// run applies the tasks concurrently. Errors of single groups don't stop the synchronization of the other groups.
+func (r *oidcGroupSetResource) run(ctx context.Context, plan oidcGroupSetResourceModel, tasks []func() diag.Diagnostics) (diags diag.Diagnostics) {
+	defer r.client.invalidateOnError(&diags)
+
+	tflog.Debug(ctx, "Synchronizing OIDC groups", map[string]any{"groups": len(plan.Groups.Elements()), "tasks": len(tasks)})
+	return runConcurrently(plan.Parallelism.ValueInt64(), tasks)
+}
+
+// deleteGroup deletes the OIDC group. Groups already deleted are ignored.
+func (r *oidcGroupSetResource) deleteGroup(ctx context.Context, p path.Path, name string, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	groupUUID, err := uuid.Parse(id)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid UUID", fmt.Sprintf("The UUID %q of OIDC Group %q is not valid: %v", id, name, err))
+		return diags
+	}
+	err = r.client.OIDC.DeleteGroup(ctx, groupUUID)
+	if err != nil && !isNotFound(err) {
+		diags.AddAttributeError(
+			p,
+			"Error deleting OIDC Group",
+			fmt.Sprintf("Could not delete OIDC Group %q, unexpected error: %v", name, err),
+		)
+		return diags
+	}
+	if err != nil {
+		tflog.Warn(ctx, "OIDC group already deleted", map[string]any{"id": id, "name": name})
+	}
+	r.client.uncacheOIDCGroup(groupUUID)
+	return diags
+}
+
+// setState reads the OIDC groups of the set back from DependencyTrack and saves them to the state.
+// Besides the listed groups, unlisted groups matching the prune prefix are read, so they show up as drift.
+func (r *oidcGroupSetResource) setState(ctx context.Context, model oidcGroupSetResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	groups, err := r.client.allOIDCGroups(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Reading DependencyTrack OIDC Groups",
+			"Could not read DependencyTrack OIDC Groups: "+err.Error(),
+		)
+		return
+	}
+	listed := model.Groups.Elements()
+	groups = slices.DeleteFunc(groups, func(group dtrack.OIDCGroup) bool {
+		_, ok := listed[group.Name]
+		return !ok && (model.PrunePrefix.IsNull() || !strings.HasPrefix(group.Name, model.PrunePrefix.ValueString()))
+	})
+	teamsByGroup, teamDiags := r.client.teamsOfGroups(ctx, groups, model.Parallelism.ValueInt64())
+	diags.Append(teamDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	groupTeams := make(map[string]attr.Value)
+	groupIDs := make(map[string]attr.Value)
+	// maps are marshaled sorted by key, so the fingerprint does not depend on the order of the groups
+	ids := make(map[string]string)
+	teamNames := make(map[string][]string)
+	for _, group := range groups {
+		groupModel := newOIDCGroupModel(group, teamsByGroup[group.UUID])
+		groupTeams[group.Name] = groupModel.Teams
+		groupIDs[group.Name] = groupModel.ID
+		ids[group.Name] = group.UUID.String()
+		teamNames[group.Name] = sortedStrings(setStrings(groupModel.Teams))
+	}
+
+	model.Groups = types.MapValueMust(types.SetType{ElemType: types.StringType}, groupTeams)
+	model.GroupIDs = types.MapValueMust(types.StringType, groupIDs)
+	model.Fingerprint = fingerprint(ids, teamNames)
+	diags.Append(state.Set(ctx, &model)...)
+}
+
+// runConcurrently runs the tasks with at most parallelism tasks at the same time and returns the diagnostics of all tasks.
+func runConcurrently(parallelism int64, tasks []func() diag.Diagnostics) diag.Diagnostics {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		diags diag.Diagnostics
+	)
+	sem := make(chan struct{}, max(parallelism, 1))
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			taskDiags := task()
+			mu.Lock()
+			defer mu.Unlock()
+			diags.Append(taskDiags...)
+		}()
+	}
+	wg.Wait()
+	return diags
+}
@@ -0,0 +1,216 @@
+package provider_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestOidcGroupSetResource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	mock.addTeam("Operators")
+	mock.addOIDCGroup("idp-developers")
+	mock.addOIDCGroup("idp-unlisted")
+	mock.addOIDCGroup("other")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "idp-developers" = ["Developers"]
+    "idp-operators"  = ["Operators", "Developers"]
+    "idp-auditors"   = []
+  }
+  prune_prefix = "idp-"
+  parallelism  = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("dependencytrack_oidc_group_set.test", "id"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.%", "3"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.idp-developers.#", "1"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.idp-operators.#", "2"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.idp-auditors.#", "0"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "group_ids.%", "3"),
+					func(_ *terraform.State) error {
+						if mock.hasOIDCGroup("idp-unlisted") {
+							return errors.New("unlisted OIDC group matching the prune prefix is not deleted")
+						}
+						if !mock.hasOIDCGroup("other") {
+							return errors.New("OIDC group not matching the prune prefix is deleted")
+						}
+						return nil
+					},
+				),
+			},
+			// Update and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "idp-developers" = ["Developers", "Operators"]
+    "idp-operators"  = ["Operators"]
+  }
+  prune_prefix = "idp-"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.%", "2"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.idp-developers.#", "2"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_group_set.test", "groups.idp-operators.*", "Operators"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.idp-operators.#", "1"),
+					func(_ *terraform.State) error {
+						if mock.hasOIDCGroup("idp-auditors") {
+							return errors.New("OIDC group removed from the set is not deleted")
+						}
+						return nil
+					},
+				),
+			},
+			// Groups matching the prune prefix created outside of Terraform are detected as drift
+			{
+				PreConfig: func() {
+					mock.addOIDCGroup("idp-manual")
+				},
+				Config: cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "idp-developers" = ["Developers", "Operators"]
+    "idp-operators"  = ["Operators"]
+  }
+  prune_prefix = "idp-"
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if mock.hasOIDCGroup("idp-developers") || mock.hasOIDCGroup("idp-operators") {
+				return errors.New("OIDC groups of the set are not deleted")
+			}
+			return nil
+		},
+	})
+}
+
+func TestOidcGroupSetResourceRemoveGroup(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "developers" = ["Developers"]
+    "auditors"   = []
+  }
+}
+`,
+				Check: resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "group_ids.%", "2"),
+			},
+			// Groups removed from the set are deleted without a prune prefix
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "developers" = ["Developers"]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "group_ids.%", "1"),
+					func(_ *terraform.State) error {
+						if mock.hasOIDCGroup("auditors") {
+							return errors.New("OIDC group removed from the set is not deleted")
+						}
+						if !mock.hasOIDCGroup("developers") {
+							return errors.New("OIDC group of the set is deleted")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestOidcGroupSetResourceMappingsNotInTeamList(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers")
+	mock.addTeam("Operators")
+	mock.addOIDCGroup("developers")
+	mock.addOIDCMapping("developers", teamID)
+	mock.setOmitOIDCMappings(true)
+	config := cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "developers" = ["Developers", "Operators"]
+  }
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The existing mapping is kept and the teams are read per group
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group_set.test", "groups.developers.#", "2"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_group_set.test", "groups.developers.*", "Developers"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_group_set.test", "groups.developers.*", "Operators"),
+				),
+			},
+			// No drift after a refresh
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestOidcGroupSetResourceUnknownTeam(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group_set" "test" {
+  groups = {
+    "developers" = ["Developers"]
+    "unknown-1"  = ["Unknown"]
+    "unknown-2"  = ["Missing"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Could not map OIDC Group "unknown-1" to Team "Unknown".*Could not map OIDC Group "unknown-2" to Team "Missing"`),
+			},
+			{
+				Config: cfg,
+				Check: func(_ *terraform.State) error {
+					if mock.hasOIDCGroup("developers") {
+						return errors.New("OIDC groups are created although teams are unknown")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// oidcGroupSetResource is the OIDC group set resource implementation.
+type oidcGroupSetResource struct {
+	client *apiClient
+}
+
+// oidcGroupSetResourceModel maps the OIDC group set resource schema data.
+type oidcGroupSetResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Groups      types.Map    `tfsdk:"groups"`
+	PrunePrefix types.String `tfsdk:"prune_prefix"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	GroupIDs    types.Map    `tfsdk:"group_ids"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &oidcGroupTeamMappingResource{}
+	_ resource.ResourceWithConfigure   = &oidcGroupTeamMappingResource{}
+	_ resource.ResourceWithImportState = &oidcGroupTeamMappingResource{}
+	_ resource.ResourceWithModifyPlan  = &oidcGroupTeamMappingResource{}
+)
+
+// NewOidcGroupTeamMappingResource is a helper function to simplify the provider implementation.
+func NewOidcGroupTeamMappingResource() resource.Resource {
+	return &oidcGroupTeamMappingResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *oidcGroupTeamMappingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the OIDC group team mapping type name.
+func (r *oidcGroupTeamMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_group_team_mapping"
+}
+
+// Schema defines the schema for the resource.
+func (r *oidcGroupTeamMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Maps an OIDC group to a team. Do not use it for groups with the teams attribute of the dependencytrack_oidc_group resource set, which removes mappings to teams not listed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the mapping.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "UUID of the OIDC group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				Description: "UUID of the team.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the mapping in DependencyTrack including the names of its group and team, " +
+					"changes when they are changed outside of Terraform.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan verifies the API token has the permissions required to manage OIDC group mappings.
+func (r *oidcGroupTeamMappingResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_oidc_group_team_mapping", dtrack.PermissionAccessManagement)...)
+}
+
+// Create maps the OIDC group to the team and sets the initial Terraform state.
+func (r *oidcGroupTeamMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oidcGroupTeamMappingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupUUID, diags := parseUUID(path.Root("group_id"), plan.GroupID)
+	resp.Diagnostics.Append(diags...)
+	teamUUID, diags := parseUUID(path.Root("team_id"), plan.TeamID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
+	// The mapping might already exist, e.g. if it is managed by the teams attribute of an OIDC group resource.
+	// The team details include the mapped OIDC groups, which are missing in team lists of some versions.
+	team, err := r.client.getTeamDetails(ctx, teamUUID)
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("team_id"),
+			"Error creating OIDC Group - Team Mapping",
+			fmt.Sprintf("Could not map OIDC Group %s to Team %s, team not found", groupUUID, teamUUID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Team",
+			fmt.Sprintf("Could not get Team %s, unexpected error: %v", teamUUID, err),
+		)
+		return
+	}
+	_, exists := findOIDCMapping([]dtrack.Team{team.Team}, groupUUID, teamUUID)
+
+	var mapping dtrack.OIDCMapping
+	if !exists {
+		mapping, err = r.client.OIDC.AddTeamMapping(ctx, dtrack.OIDCMappingRequest{Group: groupUUID, Team: teamUUID})
+	}
+	if exists || isStatus(err, http.StatusConflict) {
+		resp.Diagnostics.AddError(
+			"OIDC Group - Team Mapping exists already",
+			fmt.Sprintf("OIDC Group %s is already mapped to Team %s. The mapping might be managed by the teams attribute of a "+
+				"dependencytrack_oidc_group resource, remove it there or import the existing mapping.", groupUUID, teamUUID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OIDC Group - Team Mapping",
+			fmt.Sprintf("Could not map OIDC Group %s to Team %s, unexpected error: %v", groupUUID, teamUUID, err),
+		)
+		return
+	}
+
+	r.client.cacheOIDCMapping(teamUUID, dtrack.OIDCGroup{UUID: groupUUID}, mapping)
+
+	plan.ID = types.StringValue(mapping.UUID.String())
+	plan.Fingerprint = oidcGroupTeamMappingFingerprint(mapping, team.Team)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *oidcGroupTeamMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oidcGroupTeamMappingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The team is unknown after importing a mapping by its UUID
+	var teamUUID uuid.UUID
+	if !state.TeamID.IsNull() {
+		teamUUID, diags = parseUUID(path.Root("team_id"), state.TeamID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	mapping, team, err := r.client.getOIDCMapping(ctx, id, teamUUID)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC group team mapping not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read DependencyTrack OIDC Group - Team Mapping",
+			err.Error(),
+		)
+		return
+	}
+
+	state.GroupID = types.StringValue(mapping.Group.UUID.String())
+	state.TeamID = types.StringValue(team.UUID.String())
+	state.Fingerprint = oidcGroupTeamMappingFingerprint(mapping, team)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called, as all attributes require a replacement.
+func (r *oidcGroupTeamMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state oidcGroupTeamMappingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Fingerprint = state.Fingerprint
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the mapping and the Terraform state on success.
+func (r *oidcGroupTeamMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oidcGroupTeamMappingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
+	err := r.client.OIDC.RemoveTeamMapping(ctx, id)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC group team mapping already deleted", map[string]any{"id": state.ID.ValueString()})
+		r.client.uncacheOIDCMapping(id)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error removing OIDC Group - Team Mapping",
+			fmt.Sprintf("Could not remove OIDC Group - Team Mapping %s, unexpected error: %v", id, err),
+		)
+		return
+	}
+	r.client.uncacheOIDCMapping(id)
+}
+
+// ImportState imports a mapping by its UUID or by group and team name, e.g. "developers/Developers".
+func (r *oidcGroupTeamMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// OIDC group names may contain slashes, e.g. if the group path is used, team names are split off at the last slash.
+	i := strings.LastIndex(req.ID, "/")
+	if i <= 0 || i == len(req.ID)-1 {
+		importStateUUID(ctx, req, resp, `a mapping UUID or OIDC group and team name like "developers/Developers"`)
+		return
+	}
+	groupName, teamName := req.ID[:i], req.ID[i+1:]
+
+	groups, err := r.client.allOIDCGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting all OIDC Groups",
+			"Could not get OIDC Groups, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	teams, err := r.client.allTeams(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Teams",
+			"Could not get Teams, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, group := range groups {
+		if group.Name != groupName {
+			continue
+		}
+		for _, team := range teams {
+			if team.Name != teamName {
+				continue
+			}
+			details, err := r.client.getTeamDetails(ctx, team.UUID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error getting Team",
+					fmt.Sprintf("Could not get Team %q, unexpected error: %v", teamName, err),
+				)
+				return
+			}
+			if mapping, ok := findOIDCMapping([]dtrack.Team{details.Team}, group.UUID, team.UUID); ok {
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), mapping.UUID.String())...)
+				return
+			}
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error importing OIDC Group - Team Mapping",
+		fmt.Sprintf("Could not find a mapping of OIDC group %q to team %q", groupName, teamName),
+	)
+}
+
+// getOIDCMapping returns the mapping with the UUID and its team.
+// The mapping is looked up in the details of the team if teamUUID is set and in the details of all teams otherwise,
+// as team lists of some versions miss the mapped OIDC groups.
+// As there is no endpoint for a single mapping, a missing mapping wraps errNotFound.
+func (c *apiClient) getOIDCMapping(ctx context.Context, id uuid.UUID, teamUUID uuid.UUID) (dtrack.OIDCMapping, dtrack.Team, error) {
+	teamUUIDs := []uuid.UUID{teamUUID}
+	if teamUUID == uuid.Nil {
+		teams, err := c.allTeams(ctx)
+		if err != nil {
+			return dtrack.OIDCMapping{}, dtrack.Team{}, err
+		}
+		teamUUIDs = teamUUIDs[:0]
+		for _, team := range teams {
+			teamUUIDs = append(teamUUIDs, team.UUID)
+		}
+	}
+	for _, u := range teamUUIDs {
+		team, err := c.getTeamDetails(ctx, u)
+		if isNotFound(err) && teamUUID == uuid.Nil {
+			// the team was deleted since it was listed
+			continue
+		}
+		if err != nil {
+			return dtrack.OIDCMapping{}, dtrack.Team{}, err
+		}
+		for _, m := range team.MappedOIDCGroups {
+			if m.UUID == id {
+				return m, team.Team, nil
+			}
+		}
+	}
+	return dtrack.OIDCMapping{}, dtrack.Team{}, fmt.Errorf("OIDC group mapping %s: %w", id, errNotFound)
+}
+
+// oidcGroupTeamMappingFingerprint returns the fingerprint of the mapping in DependencyTrack,
+// including the names of the mapped group and team.
+func oidcGroupTeamMappingFingerprint(mapping dtrack.OIDCMapping, team dtrack.Team) types.String {
+	return fingerprint(mapping.UUID.String(), mapping.Group.UUID.String(), mapping.Group.Name, team.UUID.String(), team.Name)
+}
+
+// findOIDCMapping returns the mapping of the OIDC group to the team.
+func findOIDCMapping(teams []dtrack.Team, groupUUID uuid.UUID, teamUUID uuid.UUID) (dtrack.OIDCMapping, bool) {
+	for _, team := range teams {
+		if team.UUID != teamUUID {
+			continue
+		}
+		for _, m := range team.MappedOIDCGroups {
+			if m.Group.UUID == groupUUID {
+				return m, true
+			}
+		}
+	}
+	return dtrack.OIDCMapping{}, false
+}
@@ -0,0 +1,230 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestOidcGroupTeamMappingResource(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	config := cfg + `
+resource "dependencytrack_team" "test" {
+  name = "Developers"
+}
+
+resource "dependencytrack_oidc_group" "test" {
+  name = "org/developers"
+}
+
+resource "dependencytrack_oidc_group_team_mapping" "test" {
+  group_id = dependencytrack_oidc_group.test.id
+  team_id  = dependencytrack_team.test.id
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("dependencytrack_oidc_group_team_mapping.test", "id"),
+					resource.TestCheckResourceAttrPair("dependencytrack_oidc_group_team_mapping.test", "group_id", "dependencytrack_oidc_group.test", "id"),
+					resource.TestCheckResourceAttrPair("dependencytrack_oidc_group_team_mapping.test", "team_id", "dependencytrack_team.test", "id"),
+					resource.TestCheckNoResourceAttr("dependencytrack_oidc_group.test", "teams"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "dependencytrack_oidc_group_team_mapping.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by group and team name testing
+			{
+				ResourceName:      "dependencytrack_oidc_group_team_mapping.test",
+				ImportState:       true,
+				ImportStateId:     "org/developers/Developers",
+				ImportStateVerify: true,
+			},
+			// Renaming the OIDC group keeps the mapping
+			{
+				Config: regexp.MustCompile(`"org/developers"`).ReplaceAllString(config, `"org/maintainers"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_group.test", "name", "org/maintainers"),
+					resource.TestCheckResourceAttrSet("dependencytrack_oidc_group_team_mapping.test", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestOidcGroupTeamMappingResourceConflict(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name = "Developers"
+}
+
+resource "dependencytrack_oidc_group" "test" {
+  name  = "developers"
+  teams = [dependencytrack_team.test.name]
+}
+
+resource "dependencytrack_oidc_group_team_mapping" "test" {
+  group_id = dependencytrack_oidc_group.test.id
+  team_id  = dependencytrack_team.test.id
+}
+`,
+				ExpectError: regexp.MustCompile(`OIDC Group - Team Mapping exists already`),
+			},
+		},
+	})
+}
+
+func TestOidcGroupTeamMappingResourceMappingsNotInTeamList(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers")
+	groupID := mock.addOIDCGroup("developers")
+	mock.addOIDCMapping("developers", teamID)
+	mock.setOmitOIDCMappings(true)
+	config := cfg + fmt.Sprintf(`
+resource "dependencytrack_oidc_group_team_mapping" "test" {
+  group_id = %q
+  team_id  = %q
+}
+`, groupID, teamID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The existing mapping is found in the team details
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`OIDC Group - Team Mapping exists already`),
+			},
+			// ImportState by group and team name testing
+			{
+				Config:        config,
+				ResourceName:  "dependencytrack_oidc_group_team_mapping.test",
+				ImportState:   true,
+				ImportStateId: "developers/Developers",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["team_id"] != teamID.String() {
+						return fmt.Errorf("expected the mapping of team %s to be imported, got %v", teamID, states)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestOidcGroupTeamMappingResourceImportInvalidID(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_group_team_mapping" "test" {
+  group_id = "00000000-0000-0000-0000-000000000000"
+  team_id  = "00000000-0000-0000-0000-000000000000"
+}
+`,
+				ResourceName:  "dependencytrack_oidc_group_team_mapping.test",
+				ImportState:   true,
+				ImportStateId: "developers",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
+
+func TestOidcGroupTeamMappingResourceDeletedOutsideTerraform(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	config := cfg + `
+resource "dependencytrack_team" "test" {
+  name = "Developers"
+}
+
+resource "dependencytrack_oidc_group" "test" {
+  name = "developers"
+}
+
+resource "dependencytrack_oidc_group_team_mapping" "test" {
+  group_id = dependencytrack_oidc_group.test.id
+  team_id  = dependencytrack_team.test.id
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The mapping removed outside of Terraform is planned to be created again
+			{
+				PreConfig: func() {
+					mock.removeOIDCMappings("developers")
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttrSet("dependencytrack_oidc_group_team_mapping.test", "id"),
+			},
+		},
+	})
+}
+
+func TestOidcGroupTeamMappingResourceFingerprint(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers")
+	groupID := mock.addOIDCGroup("developers")
+	config := cfg + fmt.Sprintf(`
+resource "dependencytrack_oidc_group_team_mapping" "test" {
+  group_id = %q
+  team_id  = %q
+}
+`, groupID, teamID)
+	fingerprintChanged := statecheck.CompareValue(compare.ValuesDiffer())
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_oidc_group_team_mapping.test", tfjsonpath.New("fingerprint")),
+				},
+			},
+			// Renaming the group outside of Terraform changes the fingerprint
+			{
+				PreConfig: func() { mock.renameOIDCGroup("developers", "engineers") },
+				Config:    config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_oidc_group_team_mapping.test", tfjsonpath.New("fingerprint")),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// oidcGroupTeamMappingResource is the OIDC group team mapping resource implementation.
+type oidcGroupTeamMappingResource struct {
+	client *apiClient
+}
+
+// oidcGroupTeamMappingResourceModel maps the OIDC group team mapping resource schema data.
+type oidcGroupTeamMappingResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	GroupID     types.String `tfsdk:"group_id"`
+	TeamID      types.String `tfsdk:"team_id"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
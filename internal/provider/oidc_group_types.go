@@ -1,29 +1,55 @@
 package provider
 
 import (
-	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // oidcGroupsDataSource is the datasource implementation.
 type oidcGroupsDataSource struct {
-	client *dtrack.Client
+	client *apiClient
+}
+
+// oidcGroupDataSource is the datasource implementation.
+type oidcGroupDataSource struct {
+	client *apiClient
 }
 
 // oidcGroupResource is the oidc group resource implementation.
 type oidcGroupResource struct {
-	client *dtrack.Client
+	client *apiClient
 }
 
-// oidcGroupDataSourceModel maps the data source schema data.
-type oidcGroupDataSourceModel struct {
-	OidcGroups []oidcGroupModel `tfsdk:"oidc_groups"`
+// oidcGroupsDataSourceModel maps the data source schema data.
+type oidcGroupsDataSourceModel struct {
+	NameRegex    types.String     `tfsdk:"name_regex"`
+	NamePrefix   types.String     `tfsdk:"name_prefix"`
+	MappedToTeam types.String     `tfsdk:"mapped_to_team"`
+	IDs          types.List       `tfsdk:"ids"`
+	Names        types.List       `tfsdk:"names"`
+	OidcGroups   []oidcGroupModel `tfsdk:"oidc_groups"`
 }
 
 // oidcGroupModel maps oidc group schema data.
 type oidcGroupModel struct {
-	ID    types.String `tfsdk:"id"`
-	Name  types.String `tfsdk:"name"`
-	Teams types.Set    `tfsdk:"teams"`
-	// LastUpdated types.String `tfsdk:"last_updated"`
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Teams   types.Set    `tfsdk:"teams"`
+	TeamIDs types.Set    `tfsdk:"team_ids"`
+}
+
+// oidcGroupResourceModel maps the oidc group resource schema data.
+type oidcGroupResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Teams         types.Set    `tfsdk:"teams"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+	Fingerprint   types.String `tfsdk:"fingerprint"`
+}
+
+// oidcGroupResourceModelV0 maps the oidc group resource schema data of version 0, without fingerprint.
+type oidcGroupResourceModelV0 struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Teams         types.Set    `tfsdk:"teams"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
 }
@@ -3,18 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ datasource.DataSource              = &oidcGroupsDataSource{}
-	_ datasource.DataSourceWithConfigure = &oidcGroupsDataSource{}
+	_ datasource.DataSource                   = &oidcGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &oidcGroupsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &oidcGroupsDataSource{}
 )
 
+// groupTeamsParallelism is the number of OIDC groups the data sources request the teams of at the same time.
+const groupTeamsParallelism = 4
+
 func NewOidcGroupsDataSource() datasource.DataSource {
 	return &oidcGroupsDataSource{}
 }
@@ -24,11 +30,11 @@ func (d *oidcGroupsDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -44,7 +50,30 @@ func (d *oidcGroupsDataSource) Metadata(_ context.Context, req datasource.Metada
 // Schema defines the schema for the data source.
 func (d *oidcGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Lists OIDC groups, optionally filtered by name and mapped team.",
 		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return OIDC groups with a name matching this regular expression.",
+				Optional:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Only return OIDC groups with a name starting with this prefix.",
+				Optional:    true,
+			},
+			"mapped_to_team": schema.StringAttribute{
+				Description: "Only return OIDC groups mapped to the team with this name or UUID.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "UUIDs of the returned OIDC groups.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"names": schema.ListAttribute{
+				Description: "Names of the returned OIDC groups.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"oidc_groups": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -56,7 +85,13 @@ func (d *oidcGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 							Computed: true,
 						},
 						"teams": schema.SetAttribute{
-							Optional:    true,
+							Description: "Names of the teams the OIDC group is mapped to.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"team_ids": schema.SetAttribute{
+							Description: "UUIDs of the teams the OIDC group is mapped to.",
+							Computed:    true,
 							ElementType: types.StringType,
 						},
 					},
@@ -66,12 +101,32 @@ func (d *oidcGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 	}
 }
 
-func (d *oidcGroupsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state oidcGroupDataSourceModel
+// ValidateConfig verifies the name_regex is a valid regular expression.
+func (d *oidcGroupsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config oidcGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := newNameFilter(config.NameRegex, config.NamePrefix)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *oidcGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state oidcGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	groups, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.OIDCGroup], error) {
-		return d.client.OIDC.GetAllGroups(ctx, po)
-	})
+	filter, diags := newNameFilter(state.NameRegex, state.NamePrefix)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.allOIDCGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read DependencyTrack OIDC Groups",
@@ -79,21 +134,62 @@ func (d *oidcGroupsDataSource) Read(ctx context.Context, _ datasource.ReadReques
 		)
 		return
 	}
+	groups = slices.DeleteFunc(groups, func(group dtrack.OIDCGroup) bool {
+		return !filter.matches(group.Name)
+	})
+
+	teamsByGroup, diags := d.client.teamsOfGroups(ctx, groups, groupTeamsParallelism)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Map response body to model
+	var ids, names []attr.Value
 	for _, group := range groups {
-		oidcgroupState := oidcGroupModel{
-			ID:   types.StringValue(group.UUID.String()),
-			Name: types.StringValue(group.Name),
+		if !mappedToTeam(teamsByGroup[group.UUID], state.MappedToTeam) {
+			continue
 		}
 
+		oidcgroupState := newOIDCGroupModel(group, teamsByGroup[group.UUID])
+
 		state.OidcGroups = append(state.OidcGroups, oidcgroupState)
+		ids = append(ids, oidcgroupState.ID)
+		names = append(names, oidcgroupState.Name)
 	}
+	state.IDs = types.ListValueMust(types.StringType, ids)
+	state.Names = types.ListValueMust(types.StringType, names)
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// mappedToTeam returns if one of the teams has the name or UUID. A null team matches all groups.
+func mappedToTeam(teams []dtrack.Team, team types.String) bool {
+	if team.IsNull() {
+		return true
+	}
+	return slices.ContainsFunc(teams, func(t dtrack.Team) bool {
+		return t.Name == team.ValueString() || t.UUID.String() == team.ValueString()
+	})
+}
+
+// newOIDCGroupModel maps the OIDC group and the teams it is mapped to.
+func newOIDCGroupModel(group dtrack.OIDCGroup, teams []dtrack.Team) oidcGroupModel {
+	names := make([]string, 0, len(teams))
+	ids := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Name)
+		ids = append(ids, team.UUID.String())
+	}
+	return oidcGroupModel{
+		ID:      types.StringValue(group.UUID.String()),
+		Name:    types.StringValue(group.Name),
+		Teams:   stringSet(names),
+		TeamIDs: stringSet(ids),
+	}
+}
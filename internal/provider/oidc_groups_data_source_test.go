@@ -0,0 +1,84 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOidcGroupsDataSource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers")
+	mock.addOIDCGroup("oidc-dev")
+	mock.addOIDCGroup("oidc-ops")
+	mock.addOIDCGroup("other")
+	mock.addOIDCMapping("oidc-dev", teamID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Filter by name prefix testing
+			{
+				Config: cfg + `data "dependencytrack_oidc_groups" "test" { name_prefix = "oidc-" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "oidc_groups.#", "2"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "names.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_groups.test", "names.*", "oidc-dev"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_groups.test", "names.*", "oidc-ops"),
+				),
+			},
+			// Filter by mapped team testing
+			{
+				Config: cfg + `data "dependencytrack_oidc_groups" "test" { mapped_to_team = "Developers" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "names.0", "oidc-dev"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "oidc_groups.0.teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_groups.test", "oidc_groups.0.teams.*", "Developers"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "oidc_groups.0.team_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_groups.test", "oidc_groups.0.team_ids.*", teamID.String()),
+				),
+			},
+			// Filter by mapped team UUID and name regex testing
+			{
+				Config: cfg + `
+data "dependencytrack_oidc_groups" "test" {
+  mapped_to_team = "` + teamID.String() + `"
+  name_regex     = "ops$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestOidcGroupsDataSourceMappingsNotInTeamList(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers")
+	mock.addOIDCGroup("oidc-dev")
+	mock.addOIDCMapping("oidc-dev", teamID)
+	mock.setOmitOIDCMappings(true)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Teams are read per group, as the team list omits the mappings
+			{
+				Config: cfg + `
+data "dependencytrack_oidc_groups" "test" { mapped_to_team = "Developers" }
+data "dependencytrack_oidc_group" "test" { name = "oidc-dev" }
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_groups.test", "names.0", "oidc-dev"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_groups.test", "oidc_groups.0.teams.*", "Developers"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_group.test", "teams.*", "Developers"),
+				),
+			},
+		},
+	})
+}
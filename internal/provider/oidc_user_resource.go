@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &oidcUserResource{}
+	_ resource.ResourceWithConfigure   = &oidcUserResource{}
+	_ resource.ResourceWithImportState = &oidcUserResource{}
+	_ resource.ResourceWithModifyPlan  = &oidcUserResource{}
+)
+
+// NewOidcUserResource is a helper function to simplify the provider implementation.
+func NewOidcUserResource() resource.Resource {
+	return &oidcUserResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *oidcUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the OIDC user type name.
+func (r *oidcUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *oidcUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages an OIDC user and the teams it is member of.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Username of the OIDC user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username of the OIDC user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subject_identifier": schema.StringAttribute{
+				Description: "Subject identifier of the OIDC user, set by DependencyTrack on the first login.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "Email of the OIDC user, set by DependencyTrack on the first login.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"teams": schema.SetAttribute{
+				Description: "Names of the teams the OIDC user is member of, including teams assigned by OIDC group synchronization. " +
+					"If not set, the team memberships are not managed by this resource.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing OIDC user with the same username on creation instead of failing, e.g. a user provisioned on login. " +
+					"Defaults to the adopt_existing setting of the provider.",
+				Optional: true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the OIDC user in DependencyTrack including its teams if managed, " +
+					"changes when the user is changed outside of Terraform.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan verifies the API token has the permissions required to manage OIDC users.
+func (r *oidcUserResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_oidc_user", dtrack.PermissionAccessManagement)...)
+}
+
+// Create creates the OIDC user and sets the initial Terraform state.
+func (r *oidcUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oidcUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve all teams before anything is written
+	teams, diags := r.resolveTeams(ctx, plan.Teams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := plan.Username.ValueString()
+	user, err := r.client.getOIDCUser(ctx, username)
+	switch {
+	case err == nil && !r.client.adopt(plan.AdoptExisting):
+		resp.Diagnostics.AddError(
+			"Error creating OIDC user",
+			fmt.Sprintf("An OIDC user with username %q exists already. Set adopt_existing to manage the existing OIDC user.", username),
+		)
+		return
+	case err == nil:
+		tflog.Info(ctx, "Adopting existing OIDC user", map[string]any{"username": username})
+	case isNotFound(err):
+		user, err = r.client.createOIDCUser(ctx, username)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating OIDC user",
+				fmt.Sprintf("Could not create OIDC user %q, unexpected error: %v", username, err),
+			)
+			return
+		}
+	default:
+		resp.Diagnostics.AddError(
+			"Error getting OIDC users",
+			"Could not get OIDC users, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// The user is saved even if memberships fail, so Terraform retries them on the next apply.
+	plan.ID = types.StringValue(username)
+	if !plan.Teams.IsNull() {
+		resp.Diagnostics.Append(r.syncTeams(ctx, user, teams)...)
+	}
+	r.setState(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *oidcUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oidcUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.getOIDCUser(ctx, state.ID.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC user not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading DependencyTrack OIDC User",
+			fmt.Sprintf("Could not read OIDC user %q: %v", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	setOIDCUserState(&state, user)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the team memberships of the OIDC user and sets the updated Terraform state on success.
+func (r *oidcUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan oidcUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without teams, the memberships are not managed by this resource.
+	if !plan.Teams.IsNull() {
+		teams, diags := r.resolveTeams(ctx, plan.Teams)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		user, err := r.client.getOIDCUser(ctx, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading DependencyTrack OIDC User",
+				fmt.Sprintf("Could not read OIDC user %q: %v", plan.ID.ValueString(), err),
+			)
+			return
+		}
+		resp.Diagnostics.Append(r.syncTeams(ctx, user, teams)...)
+	}
+	r.setState(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Delete deletes the OIDC user and removes the Terraform state on success.
+func (r *oidcUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oidcUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.deleteOIDCUser(ctx, state.ID.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "OIDC user already deleted", map[string]any{"id": state.ID.ValueString()})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting DependencyTrack OIDC User",
+			fmt.Sprintf("Could not delete OIDC user %q, unexpected error: %v", state.ID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports an OIDC user by username.
+func (r *oidcUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Read only refreshes the teams if they are known, so the memberships of imported users are imported as well.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("teams"), types.SetValueMust(types.StringType, nil))...)
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// resolveTeams returns the teams with the planned names.
+func (r *oidcUserResource) resolveTeams(ctx context.Context, planned types.Set) ([]dtrack.Team, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if planned.IsNull() {
+		return nil, diags
+	}
+
+	teams, err := r.client.allTeams(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error getting Teams",
+			"Could not get Teams, unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	teamMap := mapByID(teams, func(it dtrack.Team) string {
+		return it.Name
+	})
+
+	var resolved []dtrack.Team
+	for _, name := range setStrings(planned) {
+		team, ok := teamMap[name]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("teams"),
+				"Error adding OIDC User to Team",
+				fmt.Sprintf("Could not add OIDC user to team %q, team not found", name),
+			)
+			continue
+		}
+		resolved = append(resolved, team)
+	}
+	return resolved, diags
+}
+
+// syncTeams adds the user to the planned teams and removes it from all other teams.
+func (r *oidcUserResource) syncTeams(ctx context.Context, user oidcUser, planned []dtrack.Team) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current := mapByID(user.Teams, func(it dtrack.Team) string {
+		return it.UUID.String()
+	})
+
+	for _, team := range planned {
+		if _, ok := current[team.UUID.String()]; ok {
+			delete(current, team.UUID.String())
+			continue
+		}
+		if err := r.client.addTeamMember(ctx, user.Username, team.UUID); err != nil {
+			diags.AddAttributeError(
+				path.Root("teams"),
+				"Error adding OIDC User to Team",
+				fmt.Sprintf("Could not add OIDC user %q to team %q, unexpected error: %v", user.Username, team.Name, err),
+			)
+		}
+	}
+
+	for _, team := range current {
+		if err := r.client.removeTeamMember(ctx, user.Username, team.UUID); err != nil {
+			diags.AddAttributeError(
+				path.Root("teams"),
+				"Error removing OIDC User from Team",
+				fmt.Sprintf("Could not remove OIDC user %q from team %q, unexpected error: %v", user.Username, team.Name, err),
+			)
+		}
+	}
+	return diags
+}
+
+// setState reads the user back from DependencyTrack and saves it with the planned configuration to the state.
+func (r *oidcUserResource) setState(ctx context.Context, plan oidcUserResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	user, err := r.client.getOIDCUser(ctx, plan.ID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading DependencyTrack OIDC User",
+			fmt.Sprintf("Could not read OIDC user %q: %v", plan.ID.ValueString(), err),
+		)
+		return
+	}
+	setOIDCUserState(&plan, user)
+	diags.Append(state.Set(ctx, &plan)...)
+}
+
+// setOIDCUserState maps the user to the state. The teams are only set and part of the fingerprint if they are managed.
+func setOIDCUserState(state *oidcUserResourceModel, user oidcUser) {
+	model := newOIDCUserModel(user)
+	state.ID = types.StringValue(user.Username)
+	state.Username = model.Username
+	state.SubjectIdentifier = model.SubjectIdentifier
+	state.Email = model.Email
+	var teams []string
+	if !state.Teams.IsNull() {
+		state.Teams = model.Teams
+		teams = sortedStrings(setStrings(model.Teams))
+	}
+	state.Fingerprint = fingerprint(user.Username, user.SubjectIdentifier, user.Email, teams)
+}
+
+// newOIDCUserModel maps the OIDC user and the names of its teams.
+func newOIDCUserModel(user oidcUser) oidcUserModel {
+	names := make([]string, 0, len(user.Teams))
+	for _, team := range user.Teams {
+		names = append(names, team.Name)
+	}
+	return oidcUserModel{
+		Username:          types.StringValue(user.Username),
+		SubjectIdentifier: types.StringValue(user.SubjectIdentifier),
+		Email:             types.StringValue(user.Email),
+		Teams:             stringSet(names),
+	}
+}
@@ -0,0 +1,148 @@
+package provider_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestOidcUserResource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	mock.addTeam("Operators")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_user" "test" {
+  username = "erin"
+  teams    = ["Developers"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_user.test", "id", "erin"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_user.test", "username", "erin"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_user.test", "teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_user.test", "teams.*", "Developers"),
+					func(_ *terraform.State) error {
+						if !mock.isMember("erin", "Developers") {
+							return errors.New("erin is not member of Developers")
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "dependencytrack_oidc_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_user" "test" {
+  username = "erin"
+  teams    = ["Operators"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_user.test", "teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_user.test", "teams.*", "Operators"),
+					func(_ *terraform.State) error {
+						if mock.isMember("erin", "Developers") || !mock.isMember("erin", "Operators") {
+							return errors.New("erin is not moved from Developers to Operators")
+						}
+						return nil
+					},
+				),
+			},
+			// Unmanaged teams testing
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_user" "test" {
+  username = "erin"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("dependencytrack_oidc_user.test", "teams"),
+					func(_ *terraform.State) error {
+						if !mock.isMember("erin", "Operators") {
+							return errors.New("erin is removed from Operators")
+						}
+						return nil
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestOidcUserResourceAdoptExisting(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_user" "test" {
+  username = "dave"
+}
+`,
+				ExpectError: regexp.MustCompile(`Set adopt_existing to manage the existing OIDC user`),
+			},
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_user" "test" {
+  username       = "dave"
+  teams          = ["Developers"]
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_oidc_user.test", "subject_identifier", "4f1c2d3e"),
+					resource.TestCheckResourceAttr("dependencytrack_oidc_user.test", "email", "dave@example.com"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_oidc_user.test", "teams.*", "Developers"),
+				),
+			},
+		},
+	})
+}
+
+func TestOidcUserResourceUnknownTeam(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_oidc_user" "test" {
+  username = "erin"
+  teams    = ["Unknown"]
+}
+`,
+				ExpectError: regexp.MustCompile(`team "Unknown" not found`),
+			},
+			{
+				Config: cfg,
+				Check: func(_ *terraform.State) error {
+					if writes := mock.takeWrites(); len(writes) > 0 {
+						return fmt.Errorf("expected no writes, got %v", writes)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
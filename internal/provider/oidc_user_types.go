@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// oidcUsersDataSource is the datasource implementation.
+type oidcUsersDataSource struct {
+	client *apiClient
+}
+
+// oidcUserResource is the OIDC user resource implementation.
+type oidcUserResource struct {
+	client *apiClient
+}
+
+// oidcUsersDataSourceModel maps the data source schema data.
+type oidcUsersDataSourceModel struct {
+	OidcUsers []oidcUserModel `tfsdk:"oidc_users"`
+}
+
+// oidcUserModel maps OIDC user schema data.
+type oidcUserModel struct {
+	Username          types.String `tfsdk:"username"`
+	SubjectIdentifier types.String `tfsdk:"subject_identifier"`
+	Email             types.String `tfsdk:"email"`
+	Teams             types.Set    `tfsdk:"teams"`
+}
+
+// oidcUserResourceModel maps the OIDC user resource schema data.
+type oidcUserResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Username          types.String `tfsdk:"username"`
+	SubjectIdentifier types.String `tfsdk:"subject_identifier"`
+	Email             types.String `tfsdk:"email"`
+	Teams             types.Set    `tfsdk:"teams"`
+	AdoptExisting     types.Bool   `tfsdk:"adopt_existing"`
+	Fingerprint       types.String `tfsdk:"fingerprint"`
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	dtrack "github.com/DependencyTrack/client-go"
+)
+
+// oidcUser maps an OIDC user, which is not covered by the DependencyTrack client.
+type oidcUser struct {
+	Username          string        `json:"username"`
+	SubjectIdentifier string        `json:"subjectIdentifier,omitempty"`
+	Email             string        `json:"email,omitempty"`
+	Teams             []dtrack.Team `json:"teams,omitempty"`
+}
+
+// allOIDCUsers returns all OIDC users with their teams.
+func (c *apiClient) allOIDCUsers(ctx context.Context) ([]oidcUser, error) {
+	var users []oidcUser
+	err := c.getJSON(ctx, "/api/v1/user/oidc", &users)
+	return users, err
+}
+
+// getOIDCUser returns the OIDC user with the username.
+// As there is no endpoint for a single OIDC user, a missing user wraps errNotFound.
+func (c *apiClient) getOIDCUser(ctx context.Context, username string) (oidcUser, error) {
+	users, err := c.allOIDCUsers(ctx)
+	if err != nil {
+		return oidcUser{}, err
+	}
+	for _, u := range users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return oidcUser{}, fmt.Errorf("OIDC user %s: %w", username, errNotFound)
+}
+
+// createOIDCUser creates an OIDC user. The subject identifier and email are set by DependencyTrack on the first login.
+func (c *apiClient) createOIDCUser(ctx context.Context, username string) (oidcUser, error) {
+	var user oidcUser
+	err := c.doJSON(ctx, http.MethodPut, "/api/v1/user/oidc", oidcUser{Username: username}, &user)
+	return user, err
+}
+
+// deleteOIDCUser deletes the OIDC user.
+func (c *apiClient) deleteOIDCUser(ctx context.Context, username string) error {
+	return c.doJSON(ctx, http.MethodDelete, "/api/v1/user/oidc", oidcUser{Username: username}, nil)
+}
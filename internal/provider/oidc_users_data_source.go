@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &oidcUsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &oidcUsersDataSource{}
+)
+
+func NewOidcUsersDataSource() datasource.DataSource {
+	return &oidcUsersDataSource{}
+}
+
+func (d *oidcUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *oidcUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *oidcUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists OIDC users with their teams.",
+		Attributes: map[string]schema.Attribute{
+			"oidc_users": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							Description: "Username of the OIDC user.",
+							Computed:    true,
+						},
+						"subject_identifier": schema.StringAttribute{
+							Description: "Subject identifier of the OIDC user.",
+							Computed:    true,
+						},
+						"email": schema.StringAttribute{
+							Description: "Email of the OIDC user.",
+							Computed:    true,
+						},
+						"teams": schema.SetAttribute{
+							Description: "Names of the teams the OIDC user is member of.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *oidcUsersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state oidcUsersDataSourceModel
+
+	users, err := d.client.allOIDCUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read DependencyTrack OIDC Users",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	for _, user := range users {
+		state.OidcUsers = append(state.OidcUsers, newOIDCUserModel(user))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOidcUsersDataSource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	mock.addMember("dave", "Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: cfg + `data "dependencytrack_oidc_users" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_users.test", "oidc_users.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_users.test", "oidc_users.0.username", "dave"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_users.test", "oidc_users.0.subject_identifier", "4f1c2d3e"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_users.test", "oidc_users.0.email", "dave@example.com"),
+					resource.TestCheckResourceAttr("data.dependencytrack_oidc_users.test", "oidc_users.0.teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_oidc_users.test", "oidc_users.0.teams.*", "Developers"),
+				),
+			},
+		},
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"os"
 
-	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -21,8 +20,19 @@ var (
 
 // dependencytrackProviderModel maps provider schema data to a Go type.
 type dependencytrackProviderModel struct {
-	Host  types.String `tfsdk:"host"`
-	Token types.String `tfsdk:"token"`
+	Host                  types.String `tfsdk:"host"`
+	Token                 types.String `tfsdk:"token"`
+	Profile               types.String `tfsdk:"profile"`
+	ConfigFile            types.String `tfsdk:"config_file"`
+	Headers               types.Map    `tfsdk:"headers"`
+	CACertFile            types.String `tfsdk:"ca_cert_file"`
+	ClientCertFile        types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile         types.String `tfsdk:"client_key_file"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	SkipVersionCheck      types.Bool   `tfsdk:"skip_version_check"`
+	DisableLookupCache    types.Bool   `tfsdk:"disable_lookup_cache"`
+	AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -59,6 +69,61 @@ func (p *dependencytrackProvider) Schema(_ context.Context, _ provider.SchemaReq
 				Optional:  true,
 				Sensitive: true,
 			},
+			"profile": schema.StringAttribute{
+				Optional: true,
+				Description: "Name of the instance profile to read the connection settings from. " +
+					"Can also be set with the DEPENDENCY_TRACK_PROFILE environment variable. " +
+					"Settings of the profile take precedence over environment variables, " +
+					"explicitly configured provider attributes take precedence over the profile.",
+			},
+			"config_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path of the config file holding the instance profiles. " +
+					"Can also be set with the DEPENDENCY_TRACK_CONFIG_FILE environment variable. " +
+					"Defaults to ~/.config/dependencytrack/config.yaml.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional headers sent with every request. Merged with the headers of the profile.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a PEM file with additional CA certificates to trust.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of the PEM encoded client certificate for mutual TLS.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of the PEM encoded client key for mutual TLS.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip the verification of the server certificate.",
+			},
+			"skip_version_check": schema.BoolAttribute{
+				Optional: true,
+				Description: "Skip the check of the DependencyTrack server version against the minimum version supported by the provider. " +
+					"Only use this if the server reports a version the provider can not interpret or does not report its version at all. " +
+					"If the version can not be fetched, a warning is reported and the permissions of the API token are not checked.",
+			},
+			"disable_lookup_cache": schema.BoolAttribute{
+				Optional: true,
+				Description: "Disable the cache shared by all resources for looking up teams, permissions and OIDC groups. " +
+					"Without the cache every resource fetches these collections on its own.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Description: "Adopt existing teams, OIDC groups and repositories with the same name or identifier on creation instead of failing. " +
+					"Can be overridden by the adopt_existing attribute of the resources.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional: true,
+				Description: "Maximum number of concurrent requests sent to the DependencyTrack API by all resources and data sources. " +
+					"Requests exceeding the limit are queued. No limit is applied if not set.",
+			},
 		},
 	}
 }
@@ -95,24 +160,108 @@ func (p *dependencytrackProvider) Configure(ctx context.Context, req provider.Co
 		)
 	}
 
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() && config.MaxConcurrentRequests.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Maximum Number of Concurrent Requests",
+			"The maximum number of concurrent requests must be at least 1.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown DependencyTrack Profile",
+			"The provider cannot create the DependencyTrack API client as there is an unknown configuration value for the DependencyTrack profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the DEPENDENCY_TRACK_PROFILE environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	// Default values to environment variables, override them with
+	// the selected profile and with Terraform configuration values if set.
+
+	cfg := clientConfig{
+		host:                  os.Getenv("DEPENDENCY_TRACK_HOST"),
+		token:                 os.Getenv("DEPENDENCY_TRACK_TOKEN"),
+		headers:               make(map[string]string),
+		skipVersionCheck:      config.SkipVersionCheck.ValueBool(),
+		disableLookupCache:    config.DisableLookupCache.ValueBool(),
+		adoptExisting:         config.AdoptExisting.ValueBool(),
+		maxConcurrentRequests: config.MaxConcurrentRequests.ValueInt64(),
+	}
 
-	host := os.Getenv("DEPENDENCY_TRACK_HOST")
-	token := os.Getenv("DEPENDENCY_TRACK_TOKEN")
+	profileName := os.Getenv("DEPENDENCY_TRACK_PROFILE")
+	if !config.Profile.IsNull() {
+		profileName = config.Profile.ValueString()
+	}
+
+	if profileName != "" {
+		profile, err := loadConfiguredProfile(config, profileName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Unable to Load DependencyTrack Profile",
+				"The provider cannot load the DependencyTrack profile "+profileName+": "+err.Error(),
+			)
+			return
+		}
+		if profile.Host != "" {
+			cfg.host = profile.Host
+		}
+		if profile.Token != "" {
+			cfg.token = profile.Token
+		}
+		cfg.caCertFile = profile.TLS.CACertFile
+		cfg.clientCertFile = profile.TLS.ClientCertFile
+		cfg.clientKeyFile = profile.TLS.ClientKeyFile
+		cfg.insecureSkipVerify = profile.TLS.InsecureSkipVerify
+		for name, value := range profile.Headers {
+			cfg.headers[name] = value
+		}
+	}
 
 	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+		cfg.host = config.Host.ValueString()
 	}
 
 	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
+		cfg.token = config.Token.ValueString()
+	}
+
+	if !config.CACertFile.IsNull() {
+		cfg.caCertFile = config.CACertFile.ValueString()
+	}
+
+	if !config.ClientCertFile.IsNull() {
+		cfg.clientCertFile = config.ClientCertFile.ValueString()
+	}
+
+	if !config.ClientKeyFile.IsNull() {
+		cfg.clientKeyFile = config.ClientKeyFile.ValueString()
 	}
 
+	if !config.InsecureSkipVerify.IsNull() {
+		cfg.insecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+
+	if !config.Headers.IsNull() {
+		headers := make(map[string]string)
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for name, value := range headers {
+			cfg.headers[name] = value
+		}
+	}
+
+	host := cfg.host
+	token := cfg.token
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -121,8 +270,8 @@ func (p *dependencytrackProvider) Configure(ctx context.Context, req provider.Co
 			path.Root("host"),
 			"Missing DependencyTrack API Host",
 			"The provider cannot create the DependencyTrack API client as there is a missing or empty value for the DependencyTrack API host. "+
-				"Set the host value in the configuration or use the DEPENDENCY_TRACK_HOST environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the host value in the configuration or in the selected profile or use the DEPENDENCY_TRACK_HOST environment variable. "+
+				"If one of them is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -131,8 +280,8 @@ func (p *dependencytrackProvider) Configure(ctx context.Context, req provider.Co
 			path.Root("token"),
 			"Missing DependencyTrack API Token",
 			"The provider cannot create the DependencyTrack API client as there is a missing or empty value for the DependencyTrack API token. "+
-				"Set the token value in the configuration or use the DEPENDENCY_TRACK_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the token value in the configuration or in the selected profile or use the DEPENDENCY_TRACK_TOKEN environment variable. "+
+				"If one of them is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -147,14 +296,46 @@ func (p *dependencytrackProvider) Configure(ctx context.Context, req provider.Co
 	tflog.Debug(ctx, "Creating DependencyTrack client")
 
 	// Create a new DependencyTrack client using the configuration values
-	client, err := dtrack.NewClient(host, dtrack.WithAPIKey(token))
+	client, err := newAPIClient(cfg)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create DependencyTrack API Client",
-			"An unexpected error occurred when creating the DependencyTrack API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"DependencyTrack Client Error: "+err.Error(),
+		summary, detail := describeConnectionError(host, err)
+		resp.Diagnostics.AddAttributeError(path.Root("host"), summary, detail)
+		return
+	}
+
+	// Without the version check, servers not reporting their version are used as if they had a recent version
+	if err := client.fetchVersion(ctx); err != nil {
+		summary, detail := describeConnectionError(host, err)
+		if !cfg.skipVersionCheck {
+			resp.Diagnostics.AddAttributeError(path.Root("host"), summary, detail)
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("host"),
+			"Unknown DependencyTrack Version",
+			"The version of the DependencyTrack server could not be fetched, the provider assumes a recent version "+
+				"and does not check the permissions of the API token before applying changes.\n\n"+detail,
 		)
+	}
+
+	ctx = tflog.SetField(ctx, "dependencytrack_version", client.serverVersion())
+	tflog.Debug(ctx, "Connected to DependencyTrack")
+
+	if !cfg.skipVersionCheck {
+		if err := client.checkServerVersion(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Unsupported DependencyTrack Version",
+				"The provider does not support the version of the DependencyTrack server: "+err.Error()+". "+
+					"Upgrade the DependencyTrack server or set skip_version_check to skip this check.",
+			)
+			return
+		}
+	}
+
+	if err := client.fetchPermissions(ctx); err != nil {
+		summary, detail := describeConnectionError(host, err)
+		resp.Diagnostics.AddAttributeError(path.Root("token"), summary, detail)
 		return
 	}
 
@@ -168,7 +349,10 @@ func (p *dependencytrackProvider) Configure(ctx context.Context, req provider.Co
 func (p *dependencytrackProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewRepositoryDataSource,
+		NewRepositoriesDataSource,
+		NewOidcGroupDataSource,
 		NewOidcGroupsDataSource,
+		NewOidcUsersDataSource,
 		NewTeamDataSource,
 		NewTeamsDataSource,
 		NewConfigPropertiesDataSource,
@@ -180,7 +364,25 @@ func (p *dependencytrackProvider) Resources(_ context.Context) []func() resource
 	return []func() resource.Resource{
 		NewRepositoryResource,
 		NewOidcGroupResource,
+		NewOidcGroupSetResource,
+		NewOidcGroupTeamMappingResource,
+		NewOidcUserResource,
 		NewTeamResource,
 		NewConfigPropertyResource,
 	}
 }
+
+// loadConfiguredProfile loads the named profile from the configured config file.
+func loadConfiguredProfile(config dependencytrackProviderModel, name string) (instanceProfile, error) {
+	file := os.Getenv("DEPENDENCY_TRACK_CONFIG_FILE")
+	if !config.ConfigFile.IsNull() {
+		file = config.ConfigFile.ValueString()
+	}
+	if file == "" {
+		var err error
+		if file, err = defaultProfileFile(); err != nil {
+			return instanceProfile{}, err
+		}
+	}
+	return loadProfile(file, name)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// profileFile maps the content of the provider config file.
+//
+//	instances:
+//	  eu:
+//	    host: https://dtrack-eu.example.com
+//	    token: odt_...
+//	    tls:
+//	      ca_cert_file: /etc/ssl/certs/eu-ca.pem
+//	    headers:
+//	      X-Region: eu
+type profileFile struct {
+	Instances map[string]instanceProfile `yaml:"instances"`
+}
+
+// instanceProfile holds the connection settings of a named DependencyTrack instance.
+type instanceProfile struct {
+	Host    string            `yaml:"host"`
+	Token   string            `yaml:"token"`
+	TLS     tlsProfile        `yaml:"tls"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// tlsProfile holds the TLS settings of a named DependencyTrack instance.
+type tlsProfile struct {
+	CACertFile         string `yaml:"ca_cert_file"`
+	ClientCertFile     string `yaml:"client_cert_file"`
+	ClientKeyFile      string `yaml:"client_key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// defaultProfileFile returns the location of the config file used if none is configured.
+func defaultProfileFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "dependencytrack", "config.yaml"), nil
+}
+
+// loadProfile reads the named instance profile from the given config file.
+func loadProfile(file string, name string) (instanceProfile, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return instanceProfile{}, fmt.Errorf("config file %q does not exist", file)
+		}
+		return instanceProfile{}, err
+	}
+
+	var pf profileFile
+	if err := yaml.Unmarshal(b, &pf); err != nil {
+		return instanceProfile{}, fmt.Errorf("could not parse config file %q: %w", file, err)
+	}
+
+	profile, ok := pf.Instances[name]
+	if !ok {
+		return instanceProfile{}, fmt.Errorf("profile %q not found in config file %q", name, file)
+	}
+	return profile, nil
+}
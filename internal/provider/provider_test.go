@@ -7,18 +7,24 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"testing"
 
 	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/bakito/terraform-provider-dependencytrack/internal/provider"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 const (
-	testExistingUUID = "88888888-8888-8888-8888-888888888888"
-	testUUID         = "99999999-9999-9999-9999-999999999999"
+	testExistingUUID  = "88888888-8888-8888-8888-888888888888"
+	testUUID          = "99999999-9999-9999-9999-999999999999"
+	testServerVersion = "4.13.0"
 
 	providerConfig = `
 provider "dependencytrack" {
@@ -36,8 +42,154 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"dependencytrack": providerserver.NewProtocol6WithError(provider.New("test")()),
 }
 
+func TestProviderConfigure(t *testing.T) {
+	server, cfg := testServerWith("4.8.2")
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unsupported server version
+			{
+				Config:      cfg + `data "dependencytrack_repositories" "test" {}`,
+				ExpectError: regexp.MustCompile("Unsupported DependencyTrack Version"),
+			},
+			// Version check skipped
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  token              = "foo"
+  host               = "%s"
+  skip_version_check = true
+}
+data "dependencytrack_repositories" "test" {}
+`, server.URL),
+				Check: resource.TestCheckResourceAttr("data.dependencytrack_repositories.test", "repositories.#", "1"),
+			},
+		},
+	})
+}
+
+func TestProviderConfigureVersionUnavailable(t *testing.T) {
+	server, cfg := testServerWith("")
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg + `data "dependencytrack_repositories" "test" {}`,
+				ExpectError: regexp.MustCompile("Unexpected DependencyTrack API Response"),
+			},
+			// The missing version is only a warning if the version check is skipped
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  token              = "foo"
+  host               = "%s"
+  skip_version_check = true
+}
+data "dependencytrack_repositories" "test" {}
+`, server.URL),
+				Check: resource.TestCheckResourceAttr("data.dependencytrack_repositories.test", "repositories.#", "1"),
+			},
+		},
+	})
+}
+
+func TestProviderConfigureInvalidToken(t *testing.T) {
+	server, _ := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  token = "invalid"
+  host  = "%s"
+}
+data "dependencytrack_repositories" "test" {}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid DependencyTrack API Token"),
+			},
+		},
+	})
+}
+
+func TestProviderConfigureProfile(t *testing.T) {
+	server, _ := testServer()
+	defer server.Close()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configFile, []byte(fmt.Sprintf(`
+instances:
+  test:
+    host: %s
+    token: foo
+    headers:
+      X-Region: test
+  unreachable:
+    host: http://127.0.0.1:1
+    token: foo
+`, server.URL)), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Settings read from the profile
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  profile     = "test"
+  config_file = "%s"
+}
+data "dependencytrack_repositories" "test" {}
+`, configFile),
+				Check: resource.TestCheckResourceAttr("data.dependencytrack_repositories.test", "repositories.#", "1"),
+			},
+			// Provider attributes take precedence over the profile
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  profile     = "unreachable"
+  config_file = "%s"
+  host        = "%s"
+}
+data "dependencytrack_repositories" "test" {}
+`, configFile, server.URL),
+				Check: resource.TestCheckResourceAttr("data.dependencytrack_repositories.test", "repositories.#", "1"),
+			},
+			// Unknown profile
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  profile     = "missing"
+  config_file = "%s"
+}
+data "dependencytrack_repositories" "test" {}
+`, configFile),
+				ExpectError: regexp.MustCompile("Unable to Load DependencyTrack Profile"),
+			},
+		},
+	})
+}
+
 // server return a test server and the matching provider config.
 func testServer() (*httptest.Server, string) {
+	return testServerWith(testServerVersion, dtrack.PermissionAccessManagement, dtrack.PermissionSystemConfiguration)
+}
+
+// testServerWith return a test server reporting the given DependencyTrack version and API key permissions
+// and the matching provider config.
+func testServerWith(version string, permissions ...string) (*httptest.Server, string) {
+	svr, cfg, _ := testServerWithMock(version, permissions...)
+	return svr, cfg
+}
+
+// testServerWithMock is like testServerWith, but also returns the mock to change the server state within tests.
+func testServerWithMock(version string, permissions ...string) (*httptest.Server, string, *mockDependencyTrack) {
 	repos := make(map[string]dtrack.Repository)
 	testRepo := dtrack.Repository{
 		Type:            dtrack.RepositoryTypeGoModules,
@@ -50,33 +202,60 @@ func testServer() (*httptest.Server, string) {
 	}
 	repos[testRepo.UUID.String()] = testRepo
 
+	mock := newMockDependencyTrack()
+	mock.repos = repos
+
 	router := http.NewServeMux()
-	router.HandleFunc("/api/v1/repository", serveResponse(repos))
-	router.HandleFunc("/api/v1/repository/", serveResponse(repos))
+	router.HandleFunc("/api/v1/repository", serveResponse(mock))
+	router.HandleFunc("/api/v1/repository/", serveResponse(mock))
 	router.HandleFunc("/api/version", func(writer http.ResponseWriter, request *http.Request) {
-		b, _ := json.Marshal(&dtrack.About{})
+		// An empty version simulates a server not reporting its version
+		if version == "" {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := json.Marshal(&dtrack.About{Version: version})
 		_, _ = writer.Write(b)
 		writer.Header().Set("Content-Type", "application/json")
 		writer.WriteHeader(http.StatusOK)
 	})
+	router.HandleFunc("GET /api/v1/team/self", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("X-Api-Key") != "foo" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		team := dtrack.Team{UUID: uuid.MustParse(testExistingUUID), Name: "Automation"}
+		for _, p := range permissions {
+			team.Permissions = append(team.Permissions, dtrack.Permission{Name: p})
+		}
+		b, _ := json.Marshal(&team)
+		_, _ = writer.Write(b)
+	})
+	mock.register(router)
 	router.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
 		fmt.Printf("Missed path %q in test server!\n", request.RequestURI)
 	})
 
 	svr := httptest.NewServer(router)
-	return svr, fmt.Sprintf(providerConfig, svr.URL)
+	return svr, fmt.Sprintf(providerConfig, svr.URL), mock
 }
 
-func serveResponse(repos map[string]dtrack.Repository) func(writer http.ResponseWriter, request *http.Request) {
+func serveResponse(mock *mockDependencyTrack) func(writer http.ResponseWriter, request *http.Request) {
 	return func(writer http.ResponseWriter, request *http.Request) {
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+		repos := mock.repos
 		switch request.Method {
 		case "GET":
-			var repoList []dtrack.Repository
+			repoType := strings.TrimPrefix(strings.TrimPrefix(request.URL.Path, "/api/v1/repository"), "/")
+			repoList := []dtrack.Repository{}
 			for _, r := range repos {
-				repoList = append(repoList, r)
+				if repoType == "" || string(r.Type) == repoType {
+					repoList = append(repoList, r)
+				}
 			}
 			b, _ := json.Marshal(&repoList)
-			writer.Header().Set("X-Total-Count", fmt.Sprintf("%d", len(repos)))
+			writer.Header().Set("X-Total-Count", fmt.Sprintf("%d", len(repoList)))
 			_, _ = writer.Write(b)
 		case "DELETE":
 			path := strings.Split(request.RequestURI, "/")
@@ -87,12 +266,20 @@ func serveResponse(repos map[string]dtrack.Repository) func(writer http.Response
 			repo := dtrack.Repository{}
 			_ = json.Unmarshal(b, &repo)
 
+			order := repo.ResolutionOrder
 			if request.Method == "PUT" {
 				repo.UUID = uuid.MustParse(testUUID)
 				repo.ResolutionOrder = len(repos)
 				repo.Internal = false
 			} else if r, ok := repos[repo.UUID.String()]; ok {
 				repo.ResolutionOrder = r.ResolutionOrder
+				// DependencyTrack keeps the password if none is sent
+				if repo.Password == "" {
+					repo.Password = r.Password
+				}
+			}
+			if request.Method == "POST" && mock.applyResolutionOrder {
+				repo.ResolutionOrder = order
 			}
 
 			repos[repo.UUID.String()] = repo
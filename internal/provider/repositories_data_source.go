@@ -5,30 +5,32 @@ import (
 	"fmt"
 
 	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ datasource.DataSource              = &repositoryDataSource{}
-	_ datasource.DataSourceWithConfigure = &repositoryDataSource{}
+	_ datasource.DataSource              = &repositoriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &repositoriesDataSource{}
 )
 
-func NewRepositoryDataSource() datasource.DataSource {
-	return &repositoryDataSource{}
+func NewRepositoriesDataSource() datasource.DataSource {
+	return &repositoriesDataSource{}
 }
 
-func (d *repositoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+func (d *repositoriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -37,16 +39,35 @@ func (d *repositoryDataSource) Configure(_ context.Context, req datasource.Confi
 	d.client = client
 }
 
-func (d *repositoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+func (d *repositoriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_repositories"
 }
 
 // Schema defines the schema for the data source.
-func (d *repositoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *repositoriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Lists repositories, optionally filtered by type, enabled and internal.",
 		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "Only list repositories of this type.",
+				Optional:    true,
+				Validators:  []validator.String{repositoryTypeValidator{}},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only list repositories that are enabled (true) or disabled (false).",
+				Optional:    true,
+			},
+			"internal": schema.BoolAttribute{
+				Description: "Only list repositories that are internal (true) or not (false).",
+				Optional:    true,
+			},
+			"include_password": schema.BoolAttribute{
+				Description: "Return the passwords of the repositories. Defaults to false.",
+				Optional:    true,
+			},
 			"repositories": schema.ListNestedAttribute{
-				Computed: true,
+				Description: "The matching repositories.",
+				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
@@ -77,11 +98,13 @@ func (d *repositoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 							Computed: true,
 						},
 						"password": schema.StringAttribute{
-							Computed:  true,
-							Sensitive: true,
+							Description: "Only returned if include_password is set.",
+							Computed:    true,
+							Sensitive:   true,
 						},
-						"last_updated": schema.StringAttribute{
-							Computed: true,
+						"fingerprint": schema.StringAttribute{
+							Description: "Fingerprint of the repository in DependencyTrack, changes whenever the repository is changed.",
+							Computed:    true,
 						},
 					},
 				},
@@ -90,12 +113,14 @@ func (d *repositoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 	}
 }
 
-func (d *repositoryDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state repositoryDataSourceModel
+func (d *repositoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state repositoriesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	repos, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Repository], error) {
-		return d.client.Repository.GetAll(ctx, po)
-	})
+	repos, err := d.client.repositories(ctx, state.Type.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read DependencyTrack Repositories",
@@ -105,21 +130,18 @@ func (d *repositoryDataSource) Read(ctx context.Context, _ datasource.ReadReques
 	}
 
 	// Map response body to model
+	state.Repositories = []repositoryModel{}
 	for _, repo := range repos {
-		repositoryState := repositoryModel{
-			ID:                     types.StringValue(repo.UUID.String()),
-			Type:                   types.StringValue(string(repo.Type)),
-			Identifier:             types.StringValue(repo.Identifier),
-			Url:                    types.StringValue(repo.Url),
-			ResolutionOrder:        types.Int64Value(int64(repo.ResolutionOrder)),
-			Enabled:                types.BoolValue(repo.Enabled),
-			Internal:               types.BoolValue(repo.Internal),
-			AuthenticationRequired: types.BoolValue(repo.AuthenticationRequired),
-			Username:               types.StringValue(repo.Username),
-			Password:               types.StringValue(repo.Password),
+		if !state.Type.IsNull() && string(repo.Type) != state.Type.ValueString() {
+			continue
 		}
-
-		state.Repositories = append(state.Repositories, repositoryState)
+		if !state.Enabled.IsNull() && repo.Enabled != state.Enabled.ValueBool() {
+			continue
+		}
+		if !state.Internal.IsNull() && repo.Internal != state.Internal.ValueBool() {
+			continue
+		}
+		state.Repositories = append(state.Repositories, newRepositoryModel(repo, state.IncludePassword.ValueBool()))
 	}
 
 	// Set state
@@ -129,3 +151,56 @@ func (d *repositoryDataSource) Read(ctx context.Context, _ datasource.ReadReques
 		return
 	}
 }
+
+// repositories returns all repositories of the given type, or all repositories if repoType is empty.
+func (c *apiClient) repositories(ctx context.Context, repoType string) ([]dtrack.Repository, error) {
+	return dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Repository], error) {
+		if repoType == "" {
+			return c.Repository.GetAll(ctx, po)
+		}
+		return c.Repository.GetByType(ctx, dtrack.RepositoryType(repoType), po)
+	})
+}
+
+// getRepository returns the repository of the given type with the UUID.
+// As there is no endpoint for a single repository, a missing repository wraps errNotFound.
+func (c *apiClient) getRepository(ctx context.Context, repoType string, id uuid.UUID) (dtrack.Repository, error) {
+	repos, err := c.repositories(ctx, repoType)
+	if err != nil {
+		return dtrack.Repository{}, err
+	}
+	for _, repo := range repos {
+		if repo.UUID == id {
+			return repo, nil
+		}
+	}
+	return dtrack.Repository{}, fmt.Errorf("repository %s: %w", id, errNotFound)
+}
+
+// newRepositoryModel maps a repository to its data source model, the password is null unless included.
+func newRepositoryModel(repo dtrack.Repository, includePassword bool) repositoryModel {
+	password := types.StringNull()
+	if includePassword {
+		password = types.StringValue(repo.Password)
+	}
+	return repositoryModel{
+		ID:                     types.StringValue(repo.UUID.String()),
+		Type:                   types.StringValue(string(repo.Type)),
+		Identifier:             types.StringValue(repo.Identifier),
+		Url:                    types.StringValue(repo.Url),
+		ResolutionOrder:        types.Int64Value(int64(repo.ResolutionOrder)),
+		Enabled:                types.BoolValue(repo.Enabled),
+		Internal:               types.BoolValue(repo.Internal),
+		AuthenticationRequired: types.BoolValue(repo.AuthenticationRequired),
+		Username:               types.StringValue(repo.Username),
+		Password:               password,
+		Fingerprint:            repositoryFingerprint(repo),
+	}
+}
+
+// repositoryFingerprint returns the fingerprint of repo. The password is left out, so the fingerprint
+// is not derived from a secret.
+func repositoryFingerprint(repo dtrack.Repository) types.String {
+	repo.Password = ""
+	return fingerprint(repo)
+}
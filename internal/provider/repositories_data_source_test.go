@@ -1,6 +1,7 @@
 package provider_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -32,3 +33,54 @@ func TestRepositoriesDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestRepositoriesDataSource_filter(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	repo := `
+resource "dependencytrack_repository" "maven" {
+  url        = "https://repo.maven.apache.org/maven2"
+  identifier = "central"
+  enabled    = false
+  type       = "MAVEN"
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + repo,
+			},
+			{
+				Config: cfg + repo + `
+data "dependencytrack_repositories" "maven" {
+  type = "MAVEN"
+}
+
+data "dependencytrack_repositories" "enabled" {
+  enabled = true
+}
+
+data "dependencytrack_repositories" "internal" {
+  internal = true
+}
+
+data "dependencytrack_repositories" "all" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.maven", "repositories.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.maven", "repositories.0.identifier", "central"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.maven", "repositories.0.enabled", "false"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.enabled", "repositories.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.enabled", "repositories.0.id", testExistingUUID),
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.internal", "repositories.#", "0"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repositories.all", "repositories.#", "2"),
+				),
+			},
+			{
+				Config:      cfg + `data "dependencytrack_repositories" "test" { type = "FOO" }`,
+				ExpectError: regexp.MustCompile(`Unknown Repository Type: "FOO"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ datasource.DataSource                   = &repositoryDataSource{}
+	_ datasource.DataSourceWithConfigure      = &repositoryDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &repositoryDataSource{}
+)
+
+func NewRepositoryDataSource() datasource.DataSource {
+	return &repositoryDataSource{}
+}
+
+func (d *repositoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *repositoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository"
+}
+
+// Schema defines the schema for the data source.
+func (d *repositoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a repository by UUID or by type and identifier.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the repository. Either id or type and identifier must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the repository. Either id or type and identifier must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators:  []validator.String{repositoryTypeValidator{}},
+			},
+			"identifier": schema.StringAttribute{
+				Description: "Identifier of the repository. Either id or type and identifier must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the repository.",
+				Computed:    true,
+			},
+			"resolution_order": schema.Int64Attribute{
+				Description: "Resolution order of the repository.",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the repository is enabled.",
+				Computed:    true,
+			},
+			"internal": schema.BoolAttribute{
+				Description: "Whether the repository is internal.",
+				Computed:    true,
+			},
+			"authentication_required": schema.BoolAttribute{
+				Description: "Whether the repository requires authentication.",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Username to authenticate with the repository.",
+				Computed:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password to authenticate with the repository. Only returned if include_password is set.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"include_password": schema.BoolAttribute{
+				Description: "Return the password of the repository. Defaults to false.",
+				Optional:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the repository in DependencyTrack, changes whenever the repository is changed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig verifies either id or type and identifier are configured.
+func (d *repositoryDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config repositoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ID.IsUnknown() || config.Type.IsUnknown() || config.Identifier.IsUnknown() {
+		return
+	}
+
+	byID := !config.ID.IsNull()
+	byIdentifier := !config.Type.IsNull() || !config.Identifier.IsNull()
+	if byID == byIdentifier || (byIdentifier && (config.Type.IsNull() || config.Identifier.IsNull())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Repository Lookup",
+			"Either id or both type and identifier must be set to look up a repository.",
+		)
+	}
+}
+
+func (d *repositoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state repositoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var repoUUID uuid.UUID
+	if !state.ID.IsNull() {
+		id, diags := parseUUID(path.Root("id"), state.ID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		repoUUID = id
+	}
+
+	repos, err := d.client.repositories(ctx, state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read DependencyTrack Repositories",
+			err.Error(),
+		)
+		return
+	}
+
+	var repo *dtrack.Repository
+	for i := range repos {
+		if state.ID.IsNull() {
+			if string(repos[i].Type) == state.Type.ValueString() && repos[i].Identifier == state.Identifier.ValueString() {
+				repo = &repos[i]
+				break
+			}
+		} else if repos[i].UUID == repoUUID {
+			repo = &repos[i]
+			break
+		}
+	}
+	if repo == nil {
+		if state.ID.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("identifier"),
+				"Unable to Read DependencyTrack Repository",
+				fmt.Sprintf("Could not find a repository of type %s with identifier %q", state.Type.ValueString(), state.Identifier.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Unable to Read DependencyTrack Repository",
+				fmt.Sprintf("Could not find Repository with id %q", state.ID.ValueString()),
+			)
+		}
+		return
+	}
+
+	// Set state
+	model := newRepositoryModel(*repo, state.IncludePassword.ValueBool())
+	state.ID = model.ID
+	state.Type = model.Type
+	state.Identifier = model.Identifier
+	state.Url = model.Url
+	state.ResolutionOrder = model.ResolutionOrder
+	state.Enabled = model.Enabled
+	state.Internal = model.Internal
+	state.AuthenticationRequired = model.AuthenticationRequired
+	state.Username = model.Username
+	state.Password = model.Password
+	state.Fingerprint = model.Fingerprint
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,77 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRepositoryDataSource(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Lookup by type and identifier
+			{
+				Config: cfg + `
+data "dependencytrack_repository" "test" {
+  type       = "GO_MODULES"
+  identifier = "proxy.golang.org"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "id", testExistingUUID),
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "url", "https://proxy.golang.org"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "resolution_order", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "internal", "false"),
+				),
+			},
+			// Lookup by id
+			{
+				Config: cfg + `
+data "dependencytrack_repository" "test" {
+  id = "` + testExistingUUID + `"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "type", "GO_MODULES"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "identifier", "proxy.golang.org"),
+					resource.TestCheckResourceAttr("data.dependencytrack_repository.test", "url", "https://proxy.golang.org"),
+				),
+			},
+			// Unknown repository
+			{
+				Config: cfg + `
+data "dependencytrack_repository" "test" {
+  type       = "MAVEN"
+  identifier = "proxy.golang.org"
+}`,
+				ExpectError: regexp.MustCompile(`Could not find a repository of type MAVEN with identifier "proxy.golang.org"`),
+			},
+			{
+				Config: cfg + `
+data "dependencytrack_repository" "test" {
+  id = "` + testUUID + `"
+}`,
+				ExpectError: regexp.MustCompile(`Could not find Repository with id`),
+			},
+			// Invalid lookups
+			{
+				Config: cfg + `
+data "dependencytrack_repository" "test" {
+  type = "GO_MODULES"
+}`,
+				ExpectError: regexp.MustCompile(`Either id or both type and identifier must be set`),
+			},
+			{
+				Config: cfg + `
+data "dependencytrack_repository" "test" {
+  id         = "` + testExistingUUID + `"
+  identifier = "proxy.golang.org"
+}`,
+				ExpectError: regexp.MustCompile(`Either id or both type and identifier must be set`),
+			},
+		},
+	})
+}
@@ -3,10 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"time"
 
 	dtrack "github.com/DependencyTrack/client-go"
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,13 +14,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &repositoryResource{}
-	_ resource.ResourceWithConfigure   = &repositoryResource{}
-	_ resource.ResourceWithImportState = &repositoryResource{}
+	_ resource.Resource                 = &repositoryResource{}
+	_ resource.ResourceWithConfigure    = &repositoryResource{}
+	_ resource.ResourceWithImportState  = &repositoryResource{}
+	_ resource.ResourceWithModifyPlan   = &repositoryResource{}
+	_ resource.ResourceWithUpgradeState = &repositoryResource{}
 )
 
 // NewRepositoryResource is a helper function to simplify the provider implementation.
@@ -36,12 +37,12 @@ func (r *repositoryResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -58,6 +59,7 @@ func (r *repositoryResource) Metadata(_ context.Context, req resource.MetadataRe
 // Schema defines the schema for the resource.
 func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -65,8 +67,9 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"last_updated": schema.StringAttribute{
-				Computed: true,
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the repository in DependencyTrack, changes when the repository is changed outside of Terraform.",
+				Computed:    true,
 			},
 			"identifier": schema.StringAttribute{
 				Required: true,
@@ -79,6 +82,11 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required: true,
 			},
 			"resolution_order": schema.Int64Attribute{
+				Description: "Position of the repository among the repositories of its type when resolving components, assigned by DependencyTrack. " +
+					"The DependencyTrack API does not support changing it, so setting it only verifies the order: " +
+					"plans fail if it differs from the order in DependencyTrack, e.g. after the repositories were reordered outside of Terraform. " +
+					"It can not be set when creating a repository.",
+				Optional: true,
 				Computed: true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
@@ -106,26 +114,169 @@ func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"password": schema.StringAttribute{
+				Description: "Password to authenticate with the repository. The password is write-only and never stored in the state, " +
+					"it is sent to DependencyTrack on creation and whenever password_version changes. Requires Terraform 1.11 or later.",
 				Optional:  true,
 				Sensitive: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+				WriteOnly: true,
+			},
+			"password_version": schema.Int64Attribute{
+				Description: "Version of the password. Change it to send the password to DependencyTrack again, e.g. after rotating it.",
+				Optional:    true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing repository with the same type and identifier on creation instead of failing. " +
+					"Defaults to the adopt_existing setting of the provider.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// UpgradeState upgrades the state of older schema versions.
+func (r *repositoryResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored the password in the state, it is write-only since version 1
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                      schema.StringAttribute{Computed: true},
+					"last_updated":            schema.StringAttribute{Computed: true},
+					"identifier":              schema.StringAttribute{Required: true},
+					"type":                    schema.StringAttribute{Required: true},
+					"url":                     schema.StringAttribute{Required: true},
+					"resolution_order":        schema.Int64Attribute{Computed: true},
+					"enabled":                 schema.BoolAttribute{Required: true},
+					"internal":                schema.BoolAttribute{Optional: true},
+					"authentication_required": schema.BoolAttribute{Optional: true},
+					"username":                schema.StringAttribute{Optional: true},
+					"password":                schema.StringAttribute{Optional: true, Sensitive: true},
+					"adopt_existing":          schema.BoolAttribute{Optional: true},
 				},
 			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior repositoryResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, repositoryResourceModel{
+					ID:                     prior.ID,
+					Type:                   prior.Type,
+					Identifier:             prior.Identifier,
+					Url:                    prior.Url,
+					ResolutionOrder:        prior.ResolutionOrder,
+					Enabled:                prior.Enabled,
+					Internal:               prior.Internal,
+					AuthenticationRequired: prior.AuthenticationRequired,
+					Username:               prior.Username,
+					Password:               types.StringNull(),
+					PasswordVersion:        types.Int64Null(),
+					AdoptExisting:          prior.AdoptExisting,
+					Fingerprint:            types.StringNull(),
+				})...)
+			},
 		},
+		// Version 1 stored the wall-clock time of the last change, replaced by the fingerprint since version 2
+		1: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                      schema.StringAttribute{Computed: true},
+					"last_updated":            schema.StringAttribute{Computed: true},
+					"identifier":              schema.StringAttribute{Required: true},
+					"type":                    schema.StringAttribute{Required: true},
+					"url":                     schema.StringAttribute{Required: true},
+					"resolution_order":        schema.Int64Attribute{Optional: true, Computed: true},
+					"enabled":                 schema.BoolAttribute{Required: true},
+					"internal":                schema.BoolAttribute{Optional: true},
+					"authentication_required": schema.BoolAttribute{Optional: true},
+					"username":                schema.StringAttribute{Optional: true},
+					"password":                schema.StringAttribute{Optional: true, Sensitive: true, WriteOnly: true},
+					"password_version":        schema.Int64Attribute{Optional: true},
+					"adopt_existing":          schema.BoolAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior repositoryResourceModelV1
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, repositoryResourceModel{
+					ID:                     prior.ID,
+					Type:                   prior.Type,
+					Identifier:             prior.Identifier,
+					Url:                    prior.Url,
+					ResolutionOrder:        prior.ResolutionOrder,
+					Enabled:                prior.Enabled,
+					Internal:               prior.Internal,
+					AuthenticationRequired: prior.AuthenticationRequired,
+					Username:               prior.Username,
+					Password:               types.StringNull(),
+					PasswordVersion:        prior.PasswordVersion,
+					AdoptExisting:          prior.AdoptExisting,
+					Fingerprint:            types.StringNull(),
+				})...)
+			},
+		},
+	}
+}
+
+// ModifyPlan verifies the API token has the permissions required to manage repositories.
+func (r *repositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_repository", dtrack.PermissionSystemConfiguration)...)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// DependencyTrack assigns the resolution order on creation and ignores it on updates,
+	// so a configured order is rejected before anything is written if it differs.
+	var configured, current types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("resolution_order"), &configured)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("resolution_order"), &current)...)
+	}
+	if resp.Diagnostics.HasError() || configured.IsNull() || configured.IsUnknown() || configured.Equal(current) {
+		return
+	}
+	if current.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resolution_order"),
+			"Resolution Order not Supported",
+			"DependencyTrack assigns the resolution order of new repositories. "+
+				"Remove resolution_order until the repository is created.",
+		)
+		return
 	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("resolution_order"),
+		"Resolution Order not Supported",
+		fmt.Sprintf("The resolution order of the repository is %d in DependencyTrack, the DependencyTrack API does not support changing it to %d. "+
+			"Reorder the repositories in DependencyTrack or set resolution_order to %d.",
+			current.ValueInt64(), configured.ValueInt64(), current.ValueInt64()),
+	)
 }
 
 // Create creates the repository and sets the initial Terraform state.
 func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
-	var plan repositoryModel
+	var plan repositoryResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The write-only password is only available in the config
+	var password types.String
+	diags = req.Config.GetAttribute(ctx, path.Root("password"), &password)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get refreshed order value from DependencyTrack
 	allRepos, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Repository], error) {
 		return r.client.Repository.GetAll(ctx, po)
@@ -138,17 +289,21 @@ func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	for _, existing := range allRepos {
-		if existing.Identifier == plan.Identifier.ValueString() &&
-			existing.Type == dtrack.RepositoryType(plan.Type.ValueString()) {
-			resp.Diagnostics.AddError(
-				"Error creating team",
-				fmt.Sprintf("A repository with identifier %q exists already with UUID %q",
-					plan.Identifier.ValueString(), existing.UUID.String()),
-			)
-			return
+	var existing *dtrack.Repository
+	for i := range allRepos {
+		if allRepos[i].Identifier == plan.Identifier.ValueString() &&
+			allRepos[i].Type == dtrack.RepositoryType(plan.Type.ValueString()) {
+			existing = &allRepos[i]
 		}
 	}
+	if existing != nil && !r.client.adopt(plan.AdoptExisting) {
+		resp.Diagnostics.AddError(
+			"Error creating repository",
+			fmt.Sprintf("A repository with identifier %q exists already with UUID %q. Set adopt_existing to manage the existing repository.",
+				plan.Identifier.ValueString(), existing.UUID.String()),
+		)
+		return
+	}
 
 	repository := dtrack.Repository{
 		Type:                   dtrack.RepositoryType(plan.Type.ValueString()),
@@ -158,46 +313,70 @@ func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequ
 		Internal:               plan.Internal.ValueBool(),
 		AuthenticationRequired: plan.AuthenticationRequired.ValueBool(),
 		Username:               plan.Username.ValueString(),
-		Password:               plan.Password.ValueString(),
+		Password:               password.ValueString(),
 	}
 
-	// Create new repository
-	result, err := r.client.Repository.Create(ctx, repository)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating repository",
-			"Could not create repository, unexpected error: "+err.Error(),
-		)
-		return
+	var result dtrack.Repository
+	if existing != nil {
+		// Adopt and update existing repository
+		tflog.Info(ctx, "Adopting existing repository", map[string]any{
+			"id": existing.UUID.String(), "type": string(existing.Type), "identifier": existing.Identifier,
+		})
+		repository.UUID = existing.UUID
+		repository.ResolutionOrder = existing.ResolutionOrder
+		result, err = r.client.Repository.Update(ctx, repository)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating repository",
+				"Could not update adopted repository, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	} else {
+		// Create new repository
+		result, err = r.client.Repository.Create(ctx, repository)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating repository",
+				"Could not create repository, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(result.UUID.String())
 	plan.ResolutionOrder = types.Int64Value(int64(result.ResolutionOrder))
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.Fingerprint = repositoryFingerprint(result)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
-	var state repositoryModel
+	var state repositoryResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get refreshed order value from DependencyTrack
-	repos, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Repository], error) {
-		return r.client.Repository.GetByType(ctx, dtrack.RepositoryType(state.Type.ValueString()), po)
-	})
+	repo, err := r.client.getRepository(ctx, state.Type.ValueString(), id)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Repository not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading DependencyTrack Repositories",
@@ -206,18 +385,6 @@ func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	var repo *dtrack.Repository
-	for i := range repos {
-		r := repos[i]
-		if state.ID.ValueString() == r.UUID.String() {
-			repo = &r
-		}
-	}
-	if repo == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Overwrite items with refreshed state
 	state.ID = types.StringValue(repo.UUID.String())
 	state.Type = types.StringValue(string(repo.Type))
@@ -232,9 +399,8 @@ func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest,
 	if repo.Username != "" {
 		state.Username = types.StringValue(repo.Username)
 	}
-	if repo.Password != "" {
-		state.Password = types.StringValue(repo.Password)
-	}
+	state.Fingerprint = repositoryFingerprint(repo)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -246,15 +412,40 @@ func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest,
 // Update updates the repository and sets the updated Terraform state on success.
 func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan
-	var plan repositoryModel
+	var plan repositoryResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var state repositoryResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the write-only password if its version changed, DependencyTrack keeps the password otherwise
+	var password types.String
+	if !plan.PasswordVersion.Equal(state.PasswordVersion) {
+		diags = req.Config.GetAttribute(ctx, path.Root("password"), &password)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	id, diags := parseUUID(path.Root("id"), plan.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Send the refreshed resolution order, so servers applying the order sent do not reset it
 	repository := dtrack.Repository{
-		UUID:                   uuid.MustParse(plan.ID.ValueString()),
+		UUID:                   id,
+		ResolutionOrder:        int(state.ResolutionOrder.ValueInt64()),
 		Type:                   dtrack.RepositoryType(plan.Type.ValueString()),
 		Identifier:             plan.Identifier.ValueString(),
 		Url:                    plan.Url.ValueString(),
@@ -262,7 +453,7 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 		Internal:               plan.Internal.ValueBool(),
 		AuthenticationRequired: plan.AuthenticationRequired.ValueBool(),
 		Username:               plan.Username.ValueString(),
-		Password:               plan.Password.ValueString(),
+		Password:               password.ValueString(),
 	}
 
 	// Update existing repository
@@ -277,19 +468,16 @@ func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ResolutionOrder = types.Int64Value(int64(result.ResolutionOrder))
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.Fingerprint = repositoryFingerprint(result)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 }
 
 // Delete deletes the repository and removes the Terraform state on success.
 func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
-	var state repositoryModel
+	var state repositoryResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -297,7 +485,17 @@ func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	// Delete existing order
-	err := r.client.Repository.Delete(ctx, uuid.MustParse(state.ID.ValueString()))
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Repository.Delete(ctx, id)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Repository already deleted", map[string]any{"id": state.ID.ValueString()})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting DependencyTrack Repository",
@@ -307,7 +505,33 @@ func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// ImportState imports a repository by UUID or by type and identifier, e.g. "GO_MODULES/proxy.golang.org".
 func (r *repositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	repoType, identifier, ok := parseRepositoryImportID(req.ID)
+	if !ok {
+		importStateUUID(ctx, req, resp, `a repository UUID or type and identifier like "GO_MODULES/proxy.golang.org"`)
+		return
+	}
+
+	repos, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Repository], error) {
+		return r.client.Repository.GetByType(ctx, repoType, po)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Repositories",
+			fmt.Sprintf("Could not get Repositories of type %s, unexpected error: %v", repoType, err),
+		)
+		return
+	}
+	for _, repo := range repos {
+		if repo.Type == repoType && repo.Identifier == identifier {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repo.UUID.String())...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), string(repo.Type))...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error importing repository",
+		fmt.Sprintf("Could not find a repository of type %s with identifier %q", repoType, identifier),
+	)
 }
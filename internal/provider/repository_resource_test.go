@@ -1,16 +1,31 @@
 package provider_test
 
 import (
+	"context"
+	"fmt"
+	"regexp"
 	"testing"
 
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
-func TestRepositoryDataSource(t *testing.T) {
+func TestRepositoryResource(t *testing.T) {
 	server, cfg := testServer()
 	defer server.Close()
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The password is write-only
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -33,6 +48,7 @@ resource "dependencytrack_repository" "test" {
 					resource.TestCheckResourceAttr("dependencytrack_repository.test", "resolution_order", "1"),
 					resource.TestCheckResourceAttr("dependencytrack_repository.test", "enabled", "true"),
 					resource.TestCheckResourceAttr("dependencytrack_repository.test", "authentication_required", "false"),
+					resource.TestCheckNoResourceAttr("dependencytrack_repository.test", "password"),
 				),
 			},
 			// ImportState testing
@@ -40,9 +56,13 @@ resource "dependencytrack_repository" "test" {
 				ResourceName:      "dependencytrack_repository.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// The last_updated attribute does not exist in the HashiCups
-				// API, therefore there is no value for it during import.
-				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// ImportState by type and identifier testing
+			{
+				ResourceName:      "dependencytrack_repository.test",
+				ImportState:       true,
+				ImportStateId:     "GO_MODULES/foo",
+				ImportStateVerify: true,
 			},
 			// Update and Read testing
 			{
@@ -71,3 +91,374 @@ resource "dependencytrack_repository" "test" {
 		},
 	})
 }
+
+func TestRepositoryResourceMissingPermission(t *testing.T) {
+	server, cfg := testServerWith(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url        = "https://foo.bar"
+  identifier = "foo"
+  enabled    = true
+  type       = "GO_MODULES"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing DependencyTrack Permission"),
+			},
+		},
+	})
+}
+
+// TestRepositoryResourceReadOnlyPlan plans against the provider server directly, as the test framework
+// can't destroy the resources of a read-only token at the end of a test case.
+func TestRepositoryResourceReadOnlyPlan(t *testing.T) {
+	server, _ := testServerWith(testServerVersion)
+	defer server.Close()
+	srv := configuredProviderServer(t, server.URL)
+	ctx := context.Background()
+
+	imported, err := srv.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "dependencytrack_repository",
+		ID:       testExistingUUID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "import", imported.Diagnostics)
+	read, err := srv.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "dependencytrack_repository",
+		CurrentState: imported.ImportedResources[0].State,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "read", read.Diagnostics)
+
+	// Plans without changes do not write and need no permissions
+	plan, err := srv.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "dependencytrack_repository",
+		PriorState:       read.NewState,
+		ProposedNewState: read.NewState,
+		Config:           read.NewState,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "plan", plan.Diagnostics)
+
+	// Destroying deletes the repository and needs the permission
+	schemas, err := srv.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemas.ResourceSchemas["dependencytrack_repository"].ValueType()
+	null, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err = srv.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "dependencytrack_repository",
+		PriorState:       read.NewState,
+		ProposedNewState: &null,
+		Config:           &null,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Diagnostics) == 0 || plan.Diagnostics[0].Summary != "Missing DependencyTrack Permission" {
+		t.Fatalf("expected missing permission on destroy, got %v", plan.Diagnostics)
+	}
+}
+
+func TestRepositoryResourceAdoptExisting(t *testing.T) {
+	server, _ := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The provider setting adopts the existing repository
+			{
+				Config: fmt.Sprintf(`
+provider "dependencytrack" {
+  token          = "foo"
+  host           = "%s"
+  adopt_existing = true
+}
+resource "dependencytrack_repository" "test" {
+  url        = "https://goproxy.example.com"
+  identifier = "proxy.golang.org"
+  enabled    = true
+  type       = "GO_MODULES"
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_repository.test", "id", testExistingUUID),
+					resource.TestCheckResourceAttr("dependencytrack_repository.test", "url", "https://goproxy.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestRepositoryResourceImportInvalidID(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url        = "https://proxy.golang.org"
+  identifier = "proxy.golang.org"
+  enabled    = true
+  type       = "GO_MODULES"
+}
+`,
+				ResourceName:  "dependencytrack_repository.test",
+				ImportState:   true,
+				ImportStateId: "proxy.golang.org",
+				ExpectError:   regexp.MustCompile("Invalid Import ID"),
+			},
+		},
+	})
+}
+
+func TestRepositoryResourceResolutionOrder(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionSystemConfiguration)
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// DependencyTrack assigns the resolution order of new repositories
+			{
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url              = "https://nexus.example.com/repository/go"
+  identifier       = "nexus"
+  enabled          = true
+  type             = "GO_MODULES"
+  resolution_order = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`DependencyTrack assigns the resolution order of new repositories`),
+			},
+			{
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url        = "https://nexus.example.com/repository/go"
+  identifier = "nexus"
+  enabled    = true
+  type       = "GO_MODULES"
+}
+`,
+				Check: resource.TestCheckResourceAttr("dependencytrack_repository.test", "resolution_order", "1"),
+			},
+			// The order set by DependencyTrack is accepted
+			{
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url              = "https://nexus.example.com/repository/go"
+  identifier       = "nexus"
+  enabled          = true
+  type             = "GO_MODULES"
+  resolution_order = 1
+}
+`,
+				Check: resource.TestCheckResourceAttr("dependencytrack_repository.test", "resolution_order", "1"),
+			},
+			// DependencyTrack ignores the resolution order sent, so a different order is rejected by the plan
+			{
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url              = "https://nexus.example.com/repository/go"
+  identifier       = "nexus"
+  enabled          = true
+  type             = "GO_MODULES"
+  resolution_order = 2
+}
+`,
+				ExpectError: regexp.MustCompile(`The resolution order of the repository is 1 in DependencyTrack, the DependencyTrack API\s+does not support changing it to 2`),
+			},
+			// Without resolution_order the refreshed order is sent, so servers applying it keep it
+			{
+				PreConfig: func() {
+					mock.setApplyResolutionOrder(true)
+					mock.setResolutionOrder(testUUID, 7)
+				},
+				Config: cfg + `
+resource "dependencytrack_repository" "test" {
+  url        = "https://nexus.example.com/repository/golang"
+  identifier = "nexus"
+  enabled    = true
+  type       = "GO_MODULES"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_repository.test", "resolution_order", "7"),
+					func(_ *terraform.State) error {
+						if order := mock.resolutionOrder(testUUID); order != 7 {
+							return fmt.Errorf("expected resolution order 7, got %d", order)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestRepositoryResourceFingerprint(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionSystemConfiguration)
+	defer server.Close()
+	config := cfg + `
+resource "dependencytrack_repository" "test" {
+  url        = "https://nexus.example.com/repository/go"
+  identifier = "nexus"
+  enabled    = true
+  type       = "GO_MODULES"
+}
+`
+	fingerprintChanged := statecheck.CompareValue(compare.ValuesDiffer())
+	fingerprintKept := statecheck.CompareValue(compare.ValuesSame())
+	fingerprint := tfjsonpath.New("fingerprint")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_repository.test", fingerprint),
+				},
+			},
+			// Changes outside of Terraform change the fingerprint, even of attributes not configured
+			{
+				PreConfig: func() {
+					mock.setResolutionOrder(testUUID, 7)
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("dependencytrack_repository.test", "resolution_order", "7"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_repository.test", fingerprint),
+					fingerprintKept.AddStateValue("dependencytrack_repository.test", fingerprint),
+				},
+			},
+			// Without changes the fingerprint is kept
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintKept.AddStateValue("dependencytrack_repository.test", fingerprint),
+				},
+			},
+		},
+	})
+}
+
+func TestRepositoryResourcePassword(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionSystemConfiguration)
+	defer server.Close()
+	config := func(url, password string, version int) string {
+		return cfg + fmt.Sprintf(`
+resource "dependencytrack_repository" "test" {
+  url                     = "%s"
+  identifier              = "nexus"
+  enabled                 = true
+  type                    = "NPM"
+  authentication_required = true
+  username                = "ci"
+  password                = "%s"
+  password_version        = %d
+}
+`, url, password, version)
+	}
+	checkPassword := func(expected string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if password := mock.repositoryPassword(testUUID); password != expected {
+				return fmt.Errorf("expected repository password %q, got %q", expected, password)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("https://nexus.example.com", "first", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("dependencytrack_repository.test", "password"),
+					resource.TestCheckResourceAttr("dependencytrack_repository.test", "password_version", "1"),
+					checkPassword("first"),
+				),
+			},
+			// The password is only sent if the version changes
+			{
+				Config: config("https://nexus.example.org", "second", 1),
+				Check:  checkPassword("first"),
+			},
+			{
+				Config: config("https://nexus.example.org", "second", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("dependencytrack_repository.test", "password"),
+					checkPassword("second"),
+				),
+			},
+		},
+	})
+}
+
+func TestRepositoryResourceUpgradeV0(t *testing.T) {
+	server, _ := testServer()
+	defer server.Close()
+	srv := configuredProviderServer(t, server.URL)
+	ctx := context.Background()
+
+	upgraded, err := srv.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "dependencytrack_repository",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(`{
+  "id": "` + testUUID + `",
+  "type": "NPM",
+  "identifier": "nexus",
+  "url": "https://nexus.example.com",
+  "resolution_order": 1,
+  "enabled": true,
+  "internal": null,
+  "authentication_required": true,
+  "username": "ci",
+  "password": "secret",
+  "last_updated": "Monday, 02-Jan-06 15:04:05 MST"
+}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "upgrade", upgraded.Diagnostics)
+
+	schemas, err := srv.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := upgraded.UpgradedState.Unmarshal(schemas.ResourceSchemas["dependencytrack_repository"].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes, _ := goValue(state).(map[string]any)
+	expected := map[string]any{
+		"id":               testUUID,
+		"username":         "ci",
+		"password":         nil,
+		"password_version": nil,
+	}
+	for name, value := range expected {
+		if actual, ok := attributes[name]; !ok || actual != value {
+			t.Errorf("attribute %s: expected %#v, got %#v", name, value, actual)
+		}
+	}
+}
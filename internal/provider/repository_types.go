@@ -1,23 +1,47 @@
 package provider
 
 import (
-	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // repositoryDataSource is the datasource implementation.
 type repositoryDataSource struct {
-	client *dtrack.Client
+	client *apiClient
+}
+
+// repositoriesDataSource is the datasource implementation.
+type repositoriesDataSource struct {
+	client *apiClient
 }
 
 // repositoryResource is the resource implementation.
 type repositoryResource struct {
-	client *dtrack.Client
+	client *apiClient
+}
+
+// repositoriesDataSourceModel maps the data source schema data.
+type repositoriesDataSourceModel struct {
+	Type            types.String      `tfsdk:"type"`
+	Enabled         types.Bool        `tfsdk:"enabled"`
+	Internal        types.Bool        `tfsdk:"internal"`
+	IncludePassword types.Bool        `tfsdk:"include_password"`
+	Repositories    []repositoryModel `tfsdk:"repositories"`
 }
 
-// repositoryDataSourceModel maps the data source schema data.
+// repositoryDataSourceModel maps the repository data source schema data.
 type repositoryDataSourceModel struct {
-	Repositories []repositoryModel `tfsdk:"repositories"`
+	ID                     types.String `tfsdk:"id"`
+	Type                   types.String `tfsdk:"type"`
+	Identifier             types.String `tfsdk:"identifier"`
+	Url                    types.String `tfsdk:"url"`
+	ResolutionOrder        types.Int64  `tfsdk:"resolution_order"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	Internal               types.Bool   `tfsdk:"internal"`
+	AuthenticationRequired types.Bool   `tfsdk:"authentication_required"`
+	Username               types.String `tfsdk:"username"`
+	Password               types.String `tfsdk:"password"`
+	IncludePassword        types.Bool   `tfsdk:"include_password"`
+	Fingerprint            types.String `tfsdk:"fingerprint"`
 }
 
 // repositoryModel maps repository schema data.
@@ -32,5 +56,55 @@ type repositoryModel struct {
 	AuthenticationRequired types.Bool   `tfsdk:"authentication_required"`
 	Username               types.String `tfsdk:"username"`
 	Password               types.String `tfsdk:"password"`
+	Fingerprint            types.String `tfsdk:"fingerprint"`
+}
+
+// repositoryResourceModel maps the repository resource schema data.
+type repositoryResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	Type                   types.String `tfsdk:"type"`
+	Identifier             types.String `tfsdk:"identifier"`
+	Url                    types.String `tfsdk:"url"`
+	ResolutionOrder        types.Int64  `tfsdk:"resolution_order"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	Internal               types.Bool   `tfsdk:"internal"`
+	AuthenticationRequired types.Bool   `tfsdk:"authentication_required"`
+	Username               types.String `tfsdk:"username"`
+	Password               types.String `tfsdk:"password"`
+	PasswordVersion        types.Int64  `tfsdk:"password_version"`
+	AdoptExisting          types.Bool   `tfsdk:"adopt_existing"`
+	Fingerprint            types.String `tfsdk:"fingerprint"`
+}
+
+// repositoryResourceModelV0 maps the repository resource schema data of version 0, storing the password.
+type repositoryResourceModelV0 struct {
+	ID                     types.String `tfsdk:"id"`
+	Type                   types.String `tfsdk:"type"`
+	Identifier             types.String `tfsdk:"identifier"`
+	Url                    types.String `tfsdk:"url"`
+	ResolutionOrder        types.Int64  `tfsdk:"resolution_order"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	Internal               types.Bool   `tfsdk:"internal"`
+	AuthenticationRequired types.Bool   `tfsdk:"authentication_required"`
+	Username               types.String `tfsdk:"username"`
+	Password               types.String `tfsdk:"password"`
+	AdoptExisting          types.Bool   `tfsdk:"adopt_existing"`
+	LastUpdated            types.String `tfsdk:"last_updated"`
+}
+
+// repositoryResourceModelV1 maps the repository resource schema data of version 1, storing the time of the last change.
+type repositoryResourceModelV1 struct {
+	ID                     types.String `tfsdk:"id"`
+	Type                   types.String `tfsdk:"type"`
+	Identifier             types.String `tfsdk:"identifier"`
+	Url                    types.String `tfsdk:"url"`
+	ResolutionOrder        types.Int64  `tfsdk:"resolution_order"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	Internal               types.Bool   `tfsdk:"internal"`
+	AuthenticationRequired types.Bool   `tfsdk:"authentication_required"`
+	Username               types.String `tfsdk:"username"`
+	Password               types.String `tfsdk:"password"`
+	PasswordVersion        types.Int64  `tfsdk:"password_version"`
+	AdoptExisting          types.Bool   `tfsdk:"adopt_existing"`
 	LastUpdated            types.String `tfsdk:"last_updated"`
 }
@@ -3,16 +3,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ datasource.DataSource              = &teamDataSource{}
-	_ datasource.DataSourceWithConfigure = &teamDataSource{}
+	_ datasource.DataSource                   = &teamDataSource{}
+	_ datasource.DataSourceWithConfigure      = &teamDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &teamDataSource{}
 )
 
 func NewTeamDataSource() datasource.DataSource {
@@ -24,11 +27,11 @@ func (d *teamDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -44,49 +47,176 @@ func (d *teamDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 // Schema defines the schema for the data source.
 func (d *teamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Looks up a team by name or UUID.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed: true,
+				Description: "UUID of the team. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				Description: "Name of the team. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"permissions": schema.SetAttribute{
+				Description: "Permissions of the team.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"api_key_comments": schema.ListAttribute{
+				Description: "Comments of the API keys of the team.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"mapped_oidc_groups": schema.SetAttribute{
+				Description: "Names of the OIDC groups mapped to the team.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"mapped_ldap_groups": schema.SetAttribute{
+				Description: "Distinguished names of the LDAP groups mapped to the team.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"managed_users": schema.SetAttribute{
+				Description: "Usernames of the managed users member of the team.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"ldap_users": schema.SetAttribute{
+				Description: "Usernames of the LDAP users member of the team.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"oidc_users": schema.SetAttribute{
+				Description: "Usernames of the OIDC users member of the team.",
+				Computed:    true,
+				ElementType: types.StringType,
 			},
 		},
 	}
 }
 
-func (d *teamDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state teamModel
+// ValidateConfig verifies exactly one of id and name is configured.
+func (d *teamDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config teamDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ID.IsUnknown() || config.Name.IsUnknown() {
+		return
+	}
 
-	teams, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-		return d.client.Team.GetAll(ctx, po)
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read DependencyTrack Teams",
-			err.Error(),
+	if config.ID.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Invalid Team Lookup",
+			"Exactly one of id and name must be set to look up a team.",
 		)
+	}
+}
+
+func (d *teamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state teamDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response body to model
-	foundIt := false
-	for _, team := range teams {
-		if team.Name == state.Name.ValueString() {
-			foundIt = true
+	id := state.ID
+	if id.IsNull() {
+		teams, err := d.client.allTeams(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read DependencyTrack Teams",
+				err.Error(),
+			)
+			return
+		}
+
+		var matches []string
+		for _, team := range teams {
+			if team.Name == state.Name.ValueString() {
+				matches = append(matches, team.UUID.String())
+			}
+		}
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Unable to Read DependencyTrack Team",
+				fmt.Sprintf("Could not find Team with name %q", state.Name.ValueString()),
+			)
+			return
+		case 1:
+			id = types.StringValue(matches[0])
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Unable to Read DependencyTrack Team",
+				fmt.Sprintf("Found %d Teams with name %q, look the team up by id instead: %s",
+					len(matches), state.Name.ValueString(), strings.Join(matches, ", ")),
+			)
+			return
 		}
-		state.ID = types.StringValue(team.UUID.String())
 	}
-	if !foundIt {
+
+	teamUUID, diags := parseUUID(path.Root("id"), id)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := d.client.getTeamDetails(ctx, teamUUID)
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Unable to Read DependencyTrack Team",
+			fmt.Sprintf("Could not find Team with id %q", teamUUID),
+		)
+		return
+	}
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read DependencyTrack Team",
-			fmt.Sprintf("Could not find Team with name %q", state.Name.ValueString()),
+			err.Error(),
 		)
 		return
 	}
 
+	// Map response body to model
+	state.ID = types.StringValue(team.UUID.String())
+	state.Name = types.StringValue(team.Name)
+
+	var permissions []string
+	for _, p := range team.Permissions {
+		permissions = append(permissions, p.Name)
+	}
+	state.Permissions = stringSet(permissions)
+
+	comments := make([]attr.Value, 0, len(team.APIKeys))
+	for _, k := range team.APIKeys {
+		comments = append(comments, types.StringValue(k.Comment))
+	}
+	state.APIKeyComments = types.ListValueMust(types.StringType, comments)
+
+	var oidcGroups []string
+	for _, m := range team.MappedOIDCGroups {
+		oidcGroups = append(oidcGroups, m.Group.Name)
+	}
+	state.MappedOidcGroups = stringSet(oidcGroups)
+
+	var ldapGroups []string
+	for _, m := range team.MappedLdapGroups {
+		ldapGroups = append(ldapGroups, m.DistinguishedName)
+	}
+	state.MappedLdapGroups = stringSet(ldapGroups)
+
+	state.ManagedUsers = stringSet(usernames(team.ManagedUsers))
+	state.LdapUsers = stringSet(usernames(team.LdapUsers))
+	state.OidcUsers = stringSet(usernames(team.OidcUsers))
+
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,107 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestTeamDataSource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	teamID := mock.addTeam("Developers", "VIEW_PORTFOLIO", "BOM_UPLOAD")
+	mock.addAPIKey(teamID, "CI")
+	mock.addOIDCGroup("developers")
+	mock.addOIDCMapping("developers", teamID)
+	mock.addLdapMapping("cn=developers,ou=groups,dc=example,dc=com", teamID)
+	mock.addMember("alice", "Developers")
+	mock.addMember("carol", "Developers")
+	mock.addMember("dave", "Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by name testing
+			{
+				Config: cfg + `data "dependencytrack_team" "test" { name = "Developers" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "id", teamID.String()),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "name", "Developers"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "permissions.*", "VIEW_PORTFOLIO"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "permissions.*", "BOM_UPLOAD"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "api_key_comments.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "api_key_comments.0", "CI"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "mapped_oidc_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "mapped_oidc_groups.*", "developers"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "mapped_ldap_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "mapped_ldap_groups.*", "cn=developers,ou=groups,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "managed_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "managed_users.*", "alice"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "ldap_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "ldap_users.*", "carol"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "oidc_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_team.test", "oidc_users.*", "dave"),
+				),
+			},
+			// Read by id testing
+			{
+				Config: cfg + `data "dependencytrack_team" "test" { id = "` + teamID.String() + `" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "id", teamID.String()),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "name", "Developers"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.dependencytrack_team.test", "managed_users.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestTeamDataSourceNotFound(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg + `data "dependencytrack_team" "test" { name = "Unknown" }`,
+				ExpectError: regexp.MustCompile(`Could not find Team with name "Unknown"`),
+			},
+			{
+				Config:      cfg + `data "dependencytrack_team" "test" { id = "00000000-0000-0000-0000-000000000000" }`,
+				ExpectError: regexp.MustCompile(`Could not find Team with id`),
+			},
+		},
+	})
+}
+
+func TestTeamDataSourceAmbiguousName(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	mock.addTeam("Developers")
+	mock.addTeam("Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg + `data "dependencytrack_team" "test" { name = "Developers" }`,
+				ExpectError: regexp.MustCompile(`Found 2 Teams with name "Developers"`),
+			},
+		},
+	})
+}
+
+func TestTeamDataSourceInvalidLookup(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg + `data "dependencytrack_team" "test" {}`,
+				ExpectError: regexp.MustCompile(`Exactly one of id and name must be set`),
+			},
+		},
+	})
+}
@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// teamDetails maps a team including its members, which are not part of dtrack.Team.
+type teamDetails struct {
+	dtrack.Team
+	ManagedUsers     []teamUser               `json:"managedUsers,omitempty"`
+	LdapUsers        []teamUser               `json:"ldapUsers,omitempty"`
+	OidcUsers        []teamUser               `json:"oidcUsers,omitempty"`
+	MappedLdapGroups []dtrack.MappedLdapGroup `json:"mappedLdapGroups,omitempty"`
+}
+
+// teamUser maps a member of a team.
+type teamUser struct {
+	Username string `json:"username"`
+}
+
+// teamReference identifies a team in membership requests.
+type teamReference struct {
+	UUID uuid.UUID `json:"uuid"`
+}
+
+// getTeamDetails returns the team with its members.
+func (c *apiClient) getTeamDetails(ctx context.Context, teamUUID uuid.UUID) (teamDetails, error) {
+	var team teamDetails
+	err := c.getJSON(ctx, fmt.Sprintf("/api/v1/team/%s", teamUUID), &team)
+	return team, err
+}
+
+// updateTeam updates the name of the team and returns the team with its members.
+func (c *apiClient) updateTeam(ctx context.Context, team dtrack.Team) (teamDetails, error) {
+	var updated teamDetails
+	err := c.doJSON(ctx, http.MethodPost, "/api/v1/team", team, &updated)
+	return updated, err
+}
+
+// addTeamMember adds a managed, LDAP or OIDC user to the team. Users already member of the team are ignored.
+func (c *apiClient) addTeamMember(ctx context.Context, username string, teamUUID uuid.UUID) error {
+	err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/api/v1/user/%s/membership", url.PathEscape(username)), teamReference{UUID: teamUUID}, nil)
+	if isStatus(err, http.StatusNotModified) {
+		return nil
+	}
+	return err
+}
+
+// removeTeamMember removes a managed, LDAP or OIDC user from the team. Users not member of the team are ignored.
+func (c *apiClient) removeTeamMember(ctx context.Context, username string, teamUUID uuid.UUID) error {
+	err := c.doJSON(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/user/%s/membership", url.PathEscape(username)), teamReference{UUID: teamUUID}, nil)
+	if isStatus(err, http.StatusNotModified) {
+		return nil
+	}
+	return err
+}
+
+// syncMembership adds the configured members and group mappings missing on the team.
+// In authoritative mode, members and group mappings not configured are removed.
+// Attributes not configured are left untouched. The config is used instead of the plan,
+// as the plan of attributes not configured holds the prior state.
+func (r *teamResource) syncMembership(ctx context.Context, team teamDetails, config teamResourceModel, authoritative bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, users := range []struct {
+		attribute string
+		planned   types.Set
+		actual    []teamUser
+	}{
+		{attribute: "managed_users", planned: config.ManagedUsers, actual: team.ManagedUsers},
+		{attribute: "ldap_users", planned: config.LdapUsers, actual: team.LdapUsers},
+		{attribute: "oidc_users", planned: config.OidcUsers, actual: team.OidcUsers},
+	} {
+		if !isKnownSet(users.planned) {
+			continue
+		}
+		toAdd, toRemove := diffNames(setStrings(users.planned), usernames(users.actual))
+		for _, username := range toAdd {
+			if err := r.client.addTeamMember(ctx, username, team.UUID); err != nil {
+				diags.AddAttributeError(
+					path.Root(users.attribute),
+					"Error adding User to Team",
+					fmt.Sprintf("Could not add User %q to Team, unexpected error: %v", username, err),
+				)
+			}
+		}
+		if !authoritative {
+			continue
+		}
+		for _, username := range toRemove {
+			if err := r.client.removeTeamMember(ctx, username, team.UUID); err != nil {
+				diags.AddAttributeError(
+					path.Root(users.attribute),
+					"Error removing User from Team",
+					fmt.Sprintf("Could not remove User %q from Team, unexpected error: %v", username, err),
+				)
+			}
+		}
+	}
+
+	if isKnownSet(config.MappedOidcGroups) {
+		diags.Append(r.syncOIDCGroupMappings(ctx, team, setStrings(config.MappedOidcGroups), authoritative)...)
+	}
+
+	if isKnownSet(config.MappedLdapGroups) {
+		mappings := make(map[string]uuid.UUID)
+		for _, m := range team.MappedLdapGroups {
+			mappings[m.DistinguishedName] = m.UUID
+		}
+		toAdd, toRemove := diffNames(setStrings(config.MappedLdapGroups), slices.Collect(maps.Keys(mappings)))
+		for _, dn := range toAdd {
+			_, err := r.client.LDAP.AddMapping(ctx, dtrack.MappedLdapGroupRequest{Team: team.UUID, DistinguishedName: dn})
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("mapped_ldap_groups"),
+					"Error creating LDAP Group - Team Mapping",
+					fmt.Sprintf("Could not map LDAP Group %q to Team, unexpected error: %v", dn, err),
+				)
+			}
+		}
+		if authoritative {
+			for _, dn := range toRemove {
+				if err := r.client.LDAP.RemoveMapping(ctx, mappings[dn]); err != nil {
+					diags.AddAttributeError(
+						path.Root("mapped_ldap_groups"),
+						"Error removing LDAP Group - Team Mapping",
+						fmt.Sprintf("Could not remove mapping of LDAP Group %q to Team, unexpected error: %v", dn, err),
+					)
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+func (r *teamResource) syncOIDCGroupMappings(ctx context.Context, team teamDetails, planned []string, authoritative bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	mappings := make(map[string]uuid.UUID)
+	for _, m := range team.MappedOIDCGroups {
+		mappings[m.Group.Name] = m.UUID
+	}
+	toAdd, toRemove := diffNames(planned, slices.Collect(maps.Keys(mappings)))
+
+	if len(toAdd) > 0 {
+		groups, err := r.client.allOIDCGroups(ctx)
+		if err != nil {
+			diags.AddError(
+				"Error getting all OIDC Groups",
+				"Could not get OIDC Groups, unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+		groupsByName := mapByID(groups, func(it dtrack.OIDCGroup) string {
+			return it.Name
+		})
+		for _, name := range toAdd {
+			group, ok := groupsByName[name]
+			if !ok {
+				diags.AddAttributeError(
+					path.Root("mapped_oidc_groups"),
+					"Error mapping OIDC Group",
+					fmt.Sprintf("Could not create OIDC Group - Team Mapping, OIDC Group %q not found", name),
+				)
+				continue
+			}
+			_, err := r.client.OIDC.AddTeamMapping(ctx, dtrack.OIDCMappingRequest{Group: group.UUID, Team: team.UUID})
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("mapped_oidc_groups"),
+					"Error creating OIDC Group - Team Mapping",
+					fmt.Sprintf("Could not map OIDC Group %q to Team, unexpected error: %v", name, err),
+				)
+			}
+		}
+	}
+
+	if authoritative {
+		for _, name := range toRemove {
+			if err := r.client.OIDC.RemoveTeamMapping(ctx, mappings[name]); err != nil {
+				diags.AddAttributeError(
+					path.Root("mapped_oidc_groups"),
+					"Error removing OIDC Group - Team Mapping",
+					fmt.Sprintf("Could not remove mapping of OIDC Group %q to Team, unexpected error: %v", name, err),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+// membershipChanged returns if members or group mappings have to be synchronized,
+// because a configured attribute differs from the prior state or the membership mode changed.
+func membershipChanged(config teamResourceModel, state teamResourceModel, authoritative types.Bool) bool {
+	if !authoritative.Equal(state.AuthoritativeMembership) {
+		return true
+	}
+	for _, attrs := range [][2]types.Set{
+		{config.ManagedUsers, state.ManagedUsers},
+		{config.LdapUsers, state.LdapUsers},
+		{config.OidcUsers, state.OidcUsers},
+		{config.MappedOidcGroups, state.MappedOidcGroups},
+		{config.MappedLdapGroups, state.MappedLdapGroups},
+	} {
+		if isKnownSet(attrs[0]) && !attrs[0].Equal(attrs[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// setTeamMembership sets the members and group mappings of the team and its fingerprint to the state.
+// In non-authoritative mode only members and groups already known to the state are kept,
+// members added outside of Terraform are ignored.
+func setTeamMembership(state *teamResourceModel, team teamDetails) {
+	if state.AuthoritativeMembership.IsNull() || state.AuthoritativeMembership.IsUnknown() {
+		state.AuthoritativeMembership = types.BoolValue(true)
+	}
+	authoritative := state.AuthoritativeMembership.ValueBool()
+
+	var oidcGroups []string
+	for _, m := range team.MappedOIDCGroups {
+		oidcGroups = append(oidcGroups, m.Group.Name)
+	}
+	var ldapGroups []string
+	for _, m := range team.MappedLdapGroups {
+		ldapGroups = append(ldapGroups, m.DistinguishedName)
+	}
+	var permissions []string
+	for _, p := range team.Permissions {
+		permissions = append(permissions, p.Name)
+	}
+
+	state.ManagedUsers = memberSet(state.ManagedUsers, usernames(team.ManagedUsers), authoritative)
+	state.LdapUsers = memberSet(state.LdapUsers, usernames(team.LdapUsers), authoritative)
+	state.OidcUsers = memberSet(state.OidcUsers, usernames(team.OidcUsers), authoritative)
+	state.MappedOidcGroups = memberSet(state.MappedOidcGroups, oidcGroups, authoritative)
+	state.MappedLdapGroups = memberSet(state.MappedLdapGroups, ldapGroups, authoritative)
+	state.Fingerprint = fingerprint(
+		team.UUID,
+		team.Name,
+		sortedStrings(permissions),
+		sortedStrings(usernames(team.ManagedUsers)),
+		sortedStrings(usernames(team.LdapUsers)),
+		sortedStrings(usernames(team.OidcUsers)),
+		sortedStrings(oidcGroups),
+		sortedStrings(ldapGroups),
+	)
+}
+
+func memberSet(prior types.Set, actual []string, authoritative bool) types.Set {
+	if !authoritative && isKnownSet(prior) {
+		known := setStrings(prior)
+		actual = slices.DeleteFunc(actual, func(name string) bool {
+			return !slices.Contains(known, name)
+		})
+	}
+	return stringSet(actual)
+}
+
+// stringSet converts names to a known, possibly empty set.
+func stringSet(names []string) types.Set {
+	values := make([]attr.Value, 0, len(names))
+	for _, name := range names {
+		values = append(values, types.StringValue(name))
+	}
+	return types.SetValueMust(types.StringType, values)
+}
+
+func usernames(users []teamUser) []string {
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	return names
+}
+
+// diffNames returns the planned names missing in actual and the actual names not planned.
+func diffNames(planned []string, actual []string) (toAdd []string, toRemove []string) {
+	for _, name := range planned {
+		if !slices.Contains(actual, name) {
+			toAdd = append(toAdd, name)
+		}
+	}
+	for _, name := range actual {
+		if !slices.Contains(planned, name) {
+			toRemove = append(toRemove, name)
+		}
+	}
+	return toAdd, toRemove
+}
+
+func isKnownSet(s types.Set) bool {
+	return !s.IsNull() && !s.IsUnknown()
+}
+
+func setStrings(s types.Set) []string {
+	var values []string
+	for _, v := range s.Elements() {
+		values = append(values, valueString(v))
+	}
+	return values
+}
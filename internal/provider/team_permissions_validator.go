@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// knownPermissions is used to validate permissions if the server's permissions can not be fetched.
+// see https://github.com/DependencyTrack/dependency-track/blob/master/src/main/java/org/dependencytrack/auth/Permissions.java
+var knownPermissions = []string{
+	dtrack.PermissionAccessManagement,
+	dtrack.PermissionBOMUpload,
+	dtrack.PermissionPolicyManagement,
+	dtrack.PermissionPolicyViolationAnalysis,
+	dtrack.PermissionPortfolioManagement,
+	dtrack.PermissionProjectCreationUpload,
+	dtrack.PermissionSystemConfiguration,
+	dtrack.PermissionTagManagement,
+	dtrack.PermissionViewBadges,
+	dtrack.PermissionViewPolicyViolation,
+	dtrack.PermissionViewPortfolio,
+	dtrack.PermissionViewVulnerability,
+	dtrack.PermissionVulnerabilityAnalysis,
+	dtrack.PermissionVulnerabilityManagement,
+}
+
+// teamPermissionsValidator validates the permissions attribute against the permissions of the server.
+type teamPermissionsValidator struct {
+	client *apiClient
+}
+
+func (v teamPermissionsValidator) Description(_ context.Context) string {
+	return "Permissions must be known to the DependencyTrack server"
+}
+
+func (v teamPermissionsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v teamPermissionsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var permissions types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("permissions"), &permissions)...)
+	if resp.Diagnostics.HasError() || permissions.IsNull() || permissions.IsUnknown() {
+		return
+	}
+
+	available, fetched := v.availablePermissions(ctx)
+	for _, p := range permissions.Elements() {
+		if p.IsUnknown() || p.IsNull() {
+			continue
+		}
+		name := valueString(p)
+		if slices.Contains(available, name) {
+			continue
+		}
+		// Newer servers may support permissions not known to the provider, so only the server's permissions are enforced
+		if !fetched {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("permissions").AtSetValue(p),
+				fmt.Sprintf("Possibly Unknown Permission: %q", name),
+				"The permissions of the DependencyTrack server could not be fetched, the permission is not one of the permissions "+
+					"known to the provider. "+unknownPermissionDetail(name, available),
+			)
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("permissions").AtSetValue(p),
+			fmt.Sprintf("Unknown Permission: %q", name),
+			unknownPermissionDetail(name, available),
+		)
+	}
+}
+
+// availablePermissions returns the permission names of the server and true, or the known permissions and false
+// if the provider is not configured yet or the server's permissions can not be fetched.
+func (v teamPermissionsValidator) availablePermissions(ctx context.Context) ([]string, bool) {
+	if v.client == nil {
+		return knownPermissions, false
+	}
+	permissions, err := v.client.allPermissions(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not get Permissions, validating against known permissions", map[string]any{"error": err.Error()})
+		return knownPermissions, false
+	}
+	var names []string
+	for _, p := range permissions {
+		names = append(names, p.Name)
+	}
+	return names, true
+}
+
+// validatePermissions resolves the given permission names, returning an error for each unknown name.
+func validatePermissions(names []attr.Value, available map[string]dtrack.Permission) ([]dtrack.Permission, []error) {
+	var permissions []dtrack.Permission
+	var errs []error
+	var availableNames []string
+	for name := range available {
+		availableNames = append(availableNames, name)
+	}
+	for _, n := range names {
+		name := valueString(n)
+		p, ok := available[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("permission %q not found. %s", name, unknownPermissionDetail(name, availableNames)))
+			continue
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, errs
+}
+
+func unknownPermissionDetail(name string, available []string) string {
+	sorted := slices.Sorted(slices.Values(available))
+	if suggestion := closestName(name, sorted); suggestion != "" {
+		return fmt.Sprintf("Did you mean %q? Available permissions: %s", suggestion, strings.Join(sorted, ", "))
+	}
+	return fmt.Sprintf("Available permissions: %s", strings.Join(sorted, ", "))
+}
+
+// closestName returns the candidate most similar to name, or an empty string if no candidate is similar enough.
+func closestName(name string, candidates []string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(name))
+	best := ""
+	bestDistance := len(normalized)/3 + 1
+	for _, c := range candidates {
+		if d := levenshtein(normalized, c); d < bestDistance {
+			best = c
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
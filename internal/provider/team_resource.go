@@ -3,23 +3,31 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
 
 	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/google/uuid"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &teamResource{}
-	_ resource.ResourceWithConfigure   = &teamResource{}
-	_ resource.ResourceWithImportState = &teamResource{}
+	_ resource.Resource                     = &teamResource{}
+	_ resource.ResourceWithConfigure        = &teamResource{}
+	_ resource.ResourceWithImportState      = &teamResource{}
+	_ resource.ResourceWithModifyPlan       = &teamResource{}
+	_ resource.ResourceWithConfigValidators = &teamResource{}
+	_ resource.ResourceWithUpgradeState     = &teamResource{}
 )
 
 // NewTeamResource is a helper function to simplify the provider implementation.
@@ -33,12 +41,12 @@ func (r *teamResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -55,6 +63,7 @@ func (r *teamResource) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the schema for the resource.
 func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -66,27 +75,171 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required: true,
 			},
 			"permissions": schema.SetAttribute{
+				Description: "Names of the permissions of the team. All permissions are removed if omitted, " +
+					"except for teams adopted with adopt_existing, whose permissions are not managed if omitted.",
 				Optional:    true,
+				Computed:    true,
 				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed_users": schema.SetAttribute{
+				Description: "Usernames of the managed users member of the team. Not managed if omitted.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ldap_users": schema.SetAttribute{
+				Description: "Usernames of the LDAP users member of the team. Not managed if omitted.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"oidc_users": schema.SetAttribute{
+				Description: "Usernames of the OIDC users member of the team. Not managed if omitted.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mapped_oidc_groups": schema.SetAttribute{
+				Description: "Names of the OIDC groups mapped to the team. Not managed if omitted.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mapped_ldap_groups": schema.SetAttribute{
+				Description: "Distinguished names of the LDAP groups mapped to the team. Not managed if omitted.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing team with the same name on creation instead of failing. " +
+					"Defaults to the adopt_existing setting of the provider.",
+				Optional: true,
+			},
+			"authoritative_membership": schema.BoolAttribute{
+				Description: "If true (default), members and group mappings not configured are removed from the team. " +
+					"If false, configured members and group mappings are only added, others are left untouched.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Fingerprint of the team in DependencyTrack including its members and group mappings, " +
+					"changes when the team is changed outside of Terraform.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// UpgradeState upgrades the state of older schema versions.
+func (r *teamResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 had no fingerprint, it is set by the next refresh
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                       schema.StringAttribute{Computed: true},
+					"name":                     schema.StringAttribute{Required: true},
+					"permissions":              schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+					"managed_users":            schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+					"ldap_users":               schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+					"oidc_users":               schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+					"mapped_oidc_groups":       schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+					"mapped_ldap_groups":       schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+					"adopt_existing":           schema.BoolAttribute{Optional: true},
+					"authoritative_membership": schema.BoolAttribute{Optional: true, Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior teamResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, teamResourceModel{
+					ID:                      prior.ID,
+					Name:                    prior.Name,
+					Permissions:             prior.Permissions,
+					ManagedUsers:            prior.ManagedUsers,
+					LdapUsers:               prior.LdapUsers,
+					OidcUsers:               prior.OidcUsers,
+					MappedOidcGroups:        prior.MappedOidcGroups,
+					MappedLdapGroups:        prior.MappedLdapGroups,
+					AuthoritativeMembership: prior.AuthoritativeMembership,
+					AdoptExisting:           prior.AdoptExisting,
+					Fingerprint:             types.StringNull(),
+				})...)
 			},
 		},
 	}
 }
 
+// ConfigValidators returns the validators checking the resource configuration against the server.
+func (r *teamResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{teamPermissionsValidator{client: r.client}}
+}
+
+// teamAdoptedKey is the private state key marking teams adopted on creation.
+const teamAdoptedKey = "adopted"
+
+// ModifyPlan verifies the API token has the permissions required to manage teams
+// and plans to remove all permissions if they are omitted, unless the team was adopted.
+func (r *teamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.client.checkPermissions(req, "dependencytrack_team", dtrack.PermissionAccessManagement)...)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var permissions types.Set
+	var adoptExisting types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("permissions"), &permissions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("adopt_existing"), &adoptExisting)...)
+	adopted, diags := req.Private.GetKey(ctx, teamAdoptedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !permissions.IsNull() || adopted != nil {
+		return
+	}
+	// The permissions of a team to be adopted are only known once it is adopted
+	if req.State.Raw.IsNull() && r.client.adopt(adoptExisting) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("permissions"), types.SetValueMust(types.StringType, nil))...)
+}
+
 // Create creates the repository and sets the initial Terraform state.
 func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
-	var plan teamModel
+	var plan teamResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
 	// Get refreshed order value from DependencyTrack
-	allTeams, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-		return r.client.Team.GetAll(ctx, po)
-	})
+	allTeams, err := r.client.allTeams(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting all Teams",
@@ -95,26 +248,60 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	for _, existing := range allTeams {
-		if existing.Name == plan.Name.ValueString() {
-			resp.Diagnostics.AddError(
-				"Error creating team",
-				fmt.Sprintf("A team with name %q exists already with UUID %q",
-					plan.Name.ValueString(), existing.UUID.String()),
-			)
-			return
+	var existing *dtrack.Team
+	for i := range allTeams {
+		if allTeams[i].Name == plan.Name.ValueString() {
+			existing = &allTeams[i]
 		}
 	}
+	if existing != nil && !r.client.adopt(plan.AdoptExisting) {
+		resp.Diagnostics.AddError(
+			"Error creating team",
+			fmt.Sprintf("A team with name %q exists already with UUID %q. Set adopt_existing to manage the existing team.",
+				plan.Name.ValueString(), existing.UUID.String()),
+		)
+		return
+	}
+
+	// Resolve all permissions before anything is written
+	allPermissions, err := r.client.allPermissions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Permission",
+			fmt.Sprintf("Could not get Permissions, unexpected error: %v", err),
+		)
+		return
+	}
+	permissions, errs := validatePermissions(plan.Permissions.Elements(), mapByID(allPermissions, func(it dtrack.Permission) string {
+		return it.Name
+	}))
+	for _, err := range errs {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("permissions"),
+			"Error add permission to team",
+			"Could not add Permission to team, "+err.Error(),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config teamResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if existing != nil {
+		r.adoptExisting(ctx, *existing, permissions, plan, config, resp)
+		return
+	}
 
 	team := dtrack.Team{
 		Name:        plan.Name.ValueString(),
 		Permissions: []dtrack.Permission{},
 	}
 
-	for _, p := range plan.Permissions.Elements() {
-		team.Permissions = append(team.Permissions, dtrack.Permission{Name: valueString(p)})
-	}
-
 	// Create new team
 	result, err := r.client.Team.Create(ctx, team)
 	if err != nil {
@@ -125,44 +312,40 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	permissions, err := fetchAllMappedByUI(
-		func(po dtrack.PageOptions) (dtrack.Page[dtrack.Permission], error) {
-			return r.client.Permission.GetAll(ctx, po)
-		},
-		func(it dtrack.Permission) string {
-			return it.Name
-		},
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting Permission",
-			fmt.Sprintf("Could not get Permissions, unexpected error: %v", err),
-		)
-		return
-	}
-
-	for _, perm := range team.Permissions {
-		p, ok := permissions[perm.Name]
-		if !ok {
-			resp.Diagnostics.AddError(
-				"Error add permission to team",
-				fmt.Sprintf("Could not add Permission to team, permission %q not found", perm.Name),
-			)
-			return
-		}
-
+	for _, p := range permissions {
 		_, err := r.client.Permission.AddPermissionToTeam(ctx, p, result.UUID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error adding Permission to Team",
 				"Could not add Permission to Team, unexpected error: "+err.Error(),
 			)
+			r.rollbackCreate(ctx, result, plan, resp)
 			return
 		}
 	}
 
+	resp.Diagnostics.Append(r.syncMembership(ctx, teamDetails{Team: result}, config, plan.AuthoritativeMembership.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		r.rollbackCreate(ctx, result, plan, resp)
+		return
+	}
+
+	details, err := r.client.getTeamDetails(ctx, result.UUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading team",
+			"Could not read created team, unexpected error: "+err.Error(),
+		)
+		r.rollbackCreate(ctx, result, plan, resp)
+		return
+	}
+
+	r.client.cacheTeam(details.Team)
+
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(result.UUID.String())
+	plan.Permissions = permissionNames(details.Permissions)
+	setTeamMembership(&plan, details)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -172,35 +355,129 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
+// adoptExisting takes over an existing team with the planned name and reconciles it to the plan.
+// Other than a created team, an adopted team is not deleted if reconciling fails.
+func (r *teamResource) adoptExisting(ctx context.Context, team dtrack.Team, permissions []dtrack.Permission, plan teamResourceModel,
+	config teamResourceModel, resp *resource.CreateResponse,
+) {
+	tflog.Info(ctx, "Adopting existing team", map[string]any{"id": team.UUID.String(), "name": team.Name})
+
+	details, err := r.client.getTeamDetails(ctx, team.UUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading team",
+			"Could not read adopted team, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Without permissions, the permissions of an adopted team are not managed and kept as they are
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, teamAdoptedKey, []byte("true"))...)
+	if !config.Permissions.IsNull() {
+		resp.Diagnostics.Append(r.syncPermissions(ctx, team.UUID, details.Permissions, permissions)...)
+	}
+	resp.Diagnostics.Append(r.syncMembership(ctx, details, config, plan.AuthoritativeMembership.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	details, err = r.client.getTeamDetails(ctx, team.UUID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading team",
+			"Could not read adopted team, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	r.client.cacheTeam(details.Team)
+
+	plan.ID = types.StringValue(team.UUID.String())
+	plan.Permissions = permissionNames(details.Permissions)
+	setTeamMembership(&plan, details)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// syncPermissions adds the planned permissions missing on the team and removes the permissions not planned.
+func (r *teamResource) syncPermissions(ctx context.Context, teamUUID uuid.UUID, current []dtrack.Permission, planned []dtrack.Permission) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, p := range planned {
+		if slices.ContainsFunc(current, func(c dtrack.Permission) bool { return c.Name == p.Name }) {
+			continue
+		}
+		if _, err := r.client.Permission.AddPermissionToTeam(ctx, p, teamUUID); err != nil && !isStatus(err, http.StatusNotModified) {
+			diags.AddError(
+				"Error adding Permission to Team",
+				fmt.Sprintf("Could not add Permission %s to Team, unexpected error: %v", p.Name, err),
+			)
+		}
+	}
+	for _, p := range current {
+		if slices.ContainsFunc(planned, func(c dtrack.Permission) bool { return c.Name == p.Name }) {
+			continue
+		}
+		if _, err := r.client.Permission.RemovePermissionFromTeam(ctx, p, teamUUID); err != nil && !isStatus(err, http.StatusNotModified) {
+			diags.AddError(
+				"Error removing Permission from Team",
+				fmt.Sprintf("Could not remove Permission %s from Team, unexpected error: %v", p.Name, err),
+			)
+		}
+	}
+	return diags
+}
+
+// rollbackCreate deletes a partially created team. If the team can not be deleted, it is saved to the state,
+// so Terraform marks it as tainted and replaces it on the next apply.
+func (r *teamResource) rollbackCreate(ctx context.Context, team dtrack.Team, plan teamResourceModel, resp *resource.CreateResponse) {
+	err := r.client.Team.Delete(ctx, team)
+	if err == nil {
+		tflog.Info(ctx, "Rolled back partially created team", map[string]any{"id": team.UUID.String()})
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Error rolling back team creation",
+		fmt.Sprintf("Could not delete partially created team %q, it is kept as tainted resource and will be replaced on the next apply. "+
+			"Unexpected error: %v", team.UUID, err),
+	)
+	plan.ID = types.StringValue(team.UUID.String())
+	plan.Permissions = permissionNames(team.Permissions)
+	setTeamMembership(&plan, teamDetails{Team: team})
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
-	var state teamModel
+	var state teamResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get refreshed order value from DependencyTrack
-	team, err := r.client.Team.Get(ctx, uuid.MustParse(state.ID.ValueString()))
+	team, err := r.client.getTeamDetails(ctx, id)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Team not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Reading DependencyTrack Repositories",
-			"Could not read DependencyTrack repositories: "+err.Error(),
+			"Error Reading DependencyTrack Team",
+			"Could not read DependencyTrack team: "+err.Error(),
 		)
 		return
 	}
 
 	// Overwrite items with refreshed state
-	state.ID = types.StringValue(team.UUID.String())
-	state.Name = types.StringValue(team.Name)
-
-	var permissionNames []attr.Value
-	for _, p := range team.Permissions {
-		permissionNames = append(permissionNames, types.StringValue(p.Name))
-	}
-	state.Permissions = types.SetValueMust(types.StringType, permissionNames)
+	setTeamState(&state, team)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -210,98 +487,82 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 }
 
-// Update updates the repository and sets the updated Terraform state on success.
+// Update updates the team and sets the updated Terraform state on success.
+// Only the changes between prior state and plan are sent, the new state is taken from the server.
 func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan
-	var plan teamModel
+	var plan teamResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	var state teamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var config teamResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	team := dtrack.Team{
-		UUID:        uuid.MustParse(plan.ID.ValueString()),
-		Name:        plan.Name.ValueString(),
-		Permissions: []dtrack.Permission{},
+	id, diags := parseUUID(path.Root("id"), plan.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for _, p := range plan.Permissions.Elements() {
-		team.Permissions = append(team.Permissions, dtrack.Permission{Name: valueString(p)})
-	}
+	defer r.client.invalidateOnError(&resp.Diagnostics)
 
-	// Update existing repository
-	updatedTeam, err := r.client.Team.Update(ctx, team)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating team",
-			"Could not update team, unexpected error: "+err.Error(),
-		)
-		return
-	}
+	// team holds the latest team returned by the server, nil if outdated by later changes
+	var team *teamDetails
 
-	permissions, err := fetchAllMappedByUI(
-		func(po dtrack.PageOptions) (dtrack.Page[dtrack.Permission], error) {
-			return r.client.Permission.GetAll(ctx, po)
-		},
-		func(it dtrack.Permission) string {
-			return it.Name
-		},
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting Permission",
-			fmt.Sprintf("Could not get Permissions, unexpected error: %v", err),
-		)
-		return
+	if !plan.Name.Equal(state.Name) {
+		updated, err := r.client.updateTeam(ctx, dtrack.Team{UUID: id, Name: plan.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating team",
+				"Could not update team, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		team = &updated
 	}
 
-	teamPermissions := mapByID(updatedTeam.Permissions, func(it dtrack.Permission) string {
-		return it.Name
-	})
-
-	for _, p := range plan.Permissions.Elements() {
-		name := valueString(p)
-		if _, ok := teamPermissions[name]; !ok {
-			perm, ok := permissions[name]
-			if !ok {
-				resp.Diagnostics.AddError(
-					"Error add permission to team",
-					fmt.Sprintf("Could not add Permission to team, permission %q not found", name),
-				)
-				return
-			}
-			_, err := r.client.Permission.AddPermissionToTeam(ctx, perm, team.UUID)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error adding Permission to Team",
-					"Could not add Permission to Team, unexpected error: "+err.Error(),
-				)
-				return
-			}
-		} else {
-			delete(teamPermissions, name)
+	// Without permissions, the plan keeps the permissions of adopted teams and removes them otherwise
+	if !plan.Permissions.Equal(state.Permissions) {
+		team = nil
+		resp.Diagnostics.Append(r.syncPermissions(ctx, id, permissionsOf(state.Permissions), permissionsOf(plan.Permissions))...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
-	for _, perm := range teamPermissions {
-		p, ok := permissions[perm.Name]
-		if !ok {
+	if membershipChanged(config, state, plan.AuthoritativeMembership) {
+		details, err := r.client.getTeamDetails(ctx, id)
+		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error add permission to team",
-				fmt.Sprintf("Could not remove Permission from team, permission %q not found", perm.Name),
+				"Error reading team",
+				"Could not read team, unexpected error: "+err.Error(),
 			)
 			return
 		}
-		_, err := r.client.Permission.RemovePermissionFromTeam(ctx, p, team.UUID)
+		team = nil
+		resp.Diagnostics.Append(r.syncMembership(ctx, details, config, plan.AuthoritativeMembership.ValueBool())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if team == nil {
+		details, err := r.client.getTeamDetails(ctx, id)
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error removing Permission from Team",
-				"Could not remove Permission from Team, unexpected error: "+err.Error(),
+				"Error reading team",
+				"Could not read updated team, unexpected error: "+err.Error(),
 			)
 			return
 		}
+		team = &details
 	}
+	r.client.cacheTeam(team.Team)
+	setTeamState(&plan, *team)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -310,23 +571,61 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 }
 
+// setTeamState sets the team as returned by the server to the state.
+func setTeamState(state *teamResourceModel, team teamDetails) {
+	state.ID = types.StringValue(team.UUID.String())
+	state.Name = types.StringValue(team.Name)
+	state.Permissions = permissionNames(team.Permissions)
+	setTeamMembership(state, team)
+}
+
+// permissionNames returns the names of the permissions as known, possibly empty set.
+func permissionNames(permissions []dtrack.Permission) types.Set {
+	names := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		names = append(names, p.Name)
+	}
+	return stringSet(names)
+}
+
+func permissionsOf(names types.Set) []dtrack.Permission {
+	var permissions []dtrack.Permission
+	for _, name := range setStrings(names) {
+		permissions = append(permissions, dtrack.Permission{Name: name})
+	}
+	return permissions
+}
+
 // Delete deletes the repository and removes the Terraform state on success.
 func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
-	var state teamModel
+	var state teamResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.client.invalidateOnError(&resp.Diagnostics)
+
+	id, diags := parseUUID(path.Root("id"), state.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	team := dtrack.Team{
-		UUID: uuid.MustParse(state.ID.ValueString()),
+		UUID: id,
 		Name: state.Name.ValueString(),
 	}
 
 	// Delete existing order
 	err := r.client.Team.Delete(ctx, team)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Team already deleted", map[string]any{"id": state.ID.ValueString()})
+		r.client.uncacheTeam(id)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting DependencyTrack Team",
@@ -334,9 +633,33 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		)
 		return
 	}
+	r.client.uncacheTeam(id)
 }
 
+// ImportState imports a team by UUID or by name, e.g. "name:Developers".
 func (r *teamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	name, ok := parseNameImportID(req.ID)
+	if !ok {
+		importStateUUID(ctx, req, resp, `a team UUID or name like "name:Developers"`)
+		return
+	}
+
+	teams, err := r.client.allTeams(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting all Teams",
+			"Could not get Teams, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	for _, t := range teams {
+		if t.Name == name {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), t.UUID.String())...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error importing team",
+		fmt.Sprintf("Could not find a team with name %q", name),
+	)
 }
@@ -0,0 +1,504 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestTeamResource(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLIO", "BOM_UPLOAD"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("dependencytrack_team.test", "id"),
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "name", "Developers"),
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "permissions.*", "VIEW_PORTFOLIO"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "permissions.*", "BOM_UPLOAD"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "dependencytrack_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by name testing
+			{
+				ResourceName:      "dependencytrack_team.test",
+				ImportState:       true,
+				ImportStateId:     "name:Developers",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Maintainers"
+  permissions = ["VIEW_PORTFOLIO", "PORTFOLIO_MANAGEMENT"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "name", "Maintainers"),
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "permissions.*", "VIEW_PORTFOLIO"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "permissions.*", "PORTFOLIO_MANAGEMENT"),
+				),
+			},
+			// Removing the permissions removes them from the team
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name = "Maintainers"
+}
+`,
+				Check: resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "0"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestTeamResourceUnknownPermission(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLO"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Did you mean "VIEW_PORTFOLIO"\?`),
+			},
+		},
+	})
+}
+
+// TestTeamResourceUnknownPermissionUnconfigured validates without a configured provider like terraform validate,
+// permissions not known to the provider only warn, as newer servers may support them.
+func TestTeamResourceUnknownPermissionUnconfigured(t *testing.T) {
+	ctx := context.Background()
+	srv, err := testAccProtoV6ProviderFactories["dependencytrack"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := srv.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemas.ResourceSchemas["dependencytrack_team"].ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["name"] = tftypes.NewValue(tftypes.String, "Developers")
+	values["permissions"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "VIEW_PORTFOLIO"),
+		tftypes.NewValue(tftypes.String, "VIEW_PORTFOLO"),
+	})
+	config, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: "dependencytrack_team",
+		Config:   &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic, got %v", resp.Diagnostics)
+	}
+	if d := resp.Diagnostics[0]; d.Severity != tfprotov6.DiagnosticSeverityWarning || !strings.Contains(d.Detail, `Did you mean "VIEW_PORTFOLIO"?`) {
+		t.Fatalf("expected a warning suggesting VIEW_PORTFOLIO, got %s: %s: %s", d.Severity, d.Summary, d.Detail)
+	}
+}
+
+func TestTeamResourceRollback(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Adding the second permission fails, the team is deleted again
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLIO", "POLICY_MANAGEMENT"]
+}
+`,
+				ExpectError: regexp.MustCompile("Error adding Permission to Team"),
+			},
+			// A retry does not fail because of the partially created team
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLIO"]
+}
+`,
+				Check: resource.TestCheckResourceAttr("dependencytrack_team.test", "name", "Developers"),
+			},
+		},
+	})
+}
+
+func TestTeamResourceMembership(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	mock.addOIDCGroup("developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with members and group mappings
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name               = "Developers"
+  permissions        = ["VIEW_PORTFOLIO"]
+  managed_users      = ["alice"]
+  ldap_users         = ["carol"]
+  oidc_users         = ["dave"]
+  mapped_oidc_groups = ["developers"]
+  mapped_ldap_groups = ["cn=developers,ou=groups,dc=example,dc=com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "authoritative_membership", "true"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "managed_users.*", "alice"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "ldap_users.*", "carol"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "oidc_users.*", "dave"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "mapped_oidc_groups.*", "developers"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "mapped_ldap_groups.*", "cn=developers,ou=groups,dc=example,dc=com"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "dependencytrack_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Authoritative mode removes members not configured
+			{
+				PreConfig: func() { mock.addMember("bob", "Developers") },
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name               = "Developers"
+  permissions        = ["VIEW_PORTFOLIO"]
+  managed_users      = ["alice"]
+  mapped_oidc_groups = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "managed_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "managed_users.*", "alice"),
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "mapped_oidc_groups.#", "0"),
+					// not configured anymore, so left untouched
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "oidc_users.*", "dave"),
+				),
+			},
+			// Additive mode keeps members added outside of Terraform
+			{
+				PreConfig: func() { mock.addMember("bob", "Developers") },
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name                     = "Developers"
+  permissions              = ["VIEW_PORTFOLIO"]
+  managed_users            = ["alice"]
+  authoritative_membership = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "managed_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "managed_users.*", "alice"),
+					func(_ *terraform.State) error {
+						if !mock.isMember("bob", "Developers") {
+							return errors.New("bob was removed from the team")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestTeamResourceFingerprint(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	config := cfg + `
+resource "dependencytrack_team" "test" {
+  name                     = "Developers"
+  managed_users            = ["alice"]
+  authoritative_membership = false
+}
+`
+	fingerprintChanged := statecheck.CompareValue(compare.ValuesDiffer())
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_team.test", tfjsonpath.New("fingerprint")),
+				},
+			},
+			// Members added outside of Terraform are ignored in additive mode, but change the fingerprint
+			{
+				PreConfig: func() { mock.addMember("bob", "Developers") },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "managed_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "managed_users.*", "alice"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					fingerprintChanged.AddStateValue("dependencytrack_team.test", tfjsonpath.New("fingerprint")),
+				},
+			},
+		},
+	})
+}
+
+func TestTeamResourceAdoptExisting(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	mock.addTeam("Developers", dtrack.PermissionBOMUpload)
+	mock.addMember("bob", "Developers")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Without adoption the existing team is reported
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLIO"]
+}
+`,
+				ExpectError: regexp.MustCompile("Set adopt_existing to manage the existing team"),
+			},
+			// The existing team is adopted and reconciled to the configuration
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name           = "Developers"
+  permissions    = ["VIEW_PORTFOLIO"]
+  managed_users  = ["alice"]
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "permissions.*", "VIEW_PORTFOLIO"),
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "managed_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "managed_users.*", "alice"),
+				),
+			},
+		},
+	})
+}
+
+func TestTeamResourceAdoptExistingPermissions(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	mock.addTeam("Developers", dtrack.PermissionBOMUpload, dtrack.PermissionViewPortfolio)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Without permissions, the permissions of the adopted team are kept
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name           = "Developers"
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "2"),
+					func(_ *terraform.State) error {
+						if !mock.hasPermission("Developers", dtrack.PermissionBOMUpload) || !mock.hasPermission("Developers", dtrack.PermissionViewPortfolio) {
+							return errors.New("permissions of the adopted team were removed")
+						}
+						return nil
+					},
+				),
+			},
+			// No changes are planned for the kept permissions
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name           = "Developers"
+  adopt_existing = true
+}
+`,
+				PlanOnly: true,
+			},
+			// Configured permissions are managed
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name           = "Developers"
+  adopt_existing = true
+  permissions    = ["VIEW_PORTFOLIO"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "1"),
+					func(_ *terraform.State) error {
+						if mock.hasPermission("Developers", dtrack.PermissionBOMUpload) {
+							return errors.New("permission BOM_UPLOAD was not removed")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestTeamResourceImportInvalidID(t *testing.T) {
+	server, cfg := testServer()
+	defer server.Close()
+	config := cfg + `
+resource "dependencytrack_team" "test" {
+  name = "Developers"
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        config,
+				ResourceName:  "dependencytrack_team.test",
+				ImportState:   true,
+				ImportStateId: "name:Unknown",
+				ExpectError:   regexp.MustCompile(`Could not find a team with name "Unknown"`),
+			},
+			{
+				Config:        config,
+				ResourceName:  "dependencytrack_team.test",
+				ImportState:   true,
+				ImportStateId: "Developers",
+				ExpectError:   regexp.MustCompile("Invalid Import ID"),
+			},
+		},
+	})
+}
+
+func TestTeamResourceDeletedOutsideTerraform(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	config := cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLIO"]
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The deleted team is removed from state and created again
+			{
+				PreConfig: func() { mock.deleteTeam("Developers") },
+				Config:    config,
+				Check: func(_ *terraform.State) error {
+					if !mock.hasTeam("Developers") {
+						return errors.New("team was not created again")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestTeamResourceUpdateRequests(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement)
+	defer server.Close()
+	expectWrites := func(expected ...string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if writes := mock.takeWrites(); !slices.Equal(writes, expected) {
+				return fmt.Errorf("expected write requests %v, got %v", expected, writes)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Developers"
+  permissions = ["VIEW_PORTFOLIO", "BOM_UPLOAD"]
+}
+`,
+			},
+			// A rename is a single request
+			{
+				PreConfig: func() { mock.takeWrites() },
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Maintainers"
+  permissions = ["VIEW_PORTFOLIO", "BOM_UPLOAD"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "name", "Maintainers"),
+					expectWrites("POST /api/v1/team"),
+				),
+			},
+			// Only changed permissions are sent
+			{
+				Config: cfg + `
+resource "dependencytrack_team" "test" {
+  name        = "Maintainers"
+  permissions = ["VIEW_PORTFOLIO", "PORTFOLIO_MANAGEMENT"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dependencytrack_team.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("dependencytrack_team.test", "permissions.*", "PORTFOLIO_MANAGEMENT"),
+					expectWrites(
+						"POST /api/v1/permission/{permission}/team/{team}",
+						"DELETE /api/v1/permission/{permission}/team/{team}",
+					),
+				),
+			},
+		},
+	})
+}
@@ -3,8 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	dtrack "github.com/DependencyTrack/client-go"
+
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -12,8 +14,9 @@ import (
 )
 
 var (
-	_ datasource.DataSource              = &teamsDataSource{}
-	_ datasource.DataSourceWithConfigure = &teamsDataSource{}
+	_ datasource.DataSource                   = &teamsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &teamsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &teamsDataSource{}
 )
 
 func NewTeamsDataSource() datasource.DataSource {
@@ -25,11 +28,11 @@ func (d *teamsDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*dtrack.Client)
+	client, ok := req.ProviderData.(*apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *dtrack.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.apiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -45,7 +48,30 @@ func (d *teamsDataSource) Metadata(_ context.Context, req datasource.MetadataReq
 // Schema defines the schema for the data source.
 func (d *teamsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Lists teams, optionally filtered by name and permission.",
 		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return teams with a name matching this regular expression.",
+				Optional:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Only return teams with a name starting with this prefix.",
+				Optional:    true,
+			},
+			"has_permission": schema.StringAttribute{
+				Description: "Only return teams having this permission, e.g. BOM_UPLOAD.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "UUIDs of the returned teams.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"names": schema.ListAttribute{
+				Description: "Names of the returned teams.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"teams": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -67,12 +93,32 @@ func (d *teamsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	}
 }
 
-func (d *teamsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+// ValidateConfig verifies the name_regex is a valid regular expression.
+func (d *teamsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config teamsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := newNameFilter(config.NameRegex, config.NamePrefix)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *teamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state teamsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	teams, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Team], error) {
-		return d.client.Team.GetAll(ctx, po)
-	})
+	filter, diags := newNameFilter(state.NameRegex, state.NamePrefix)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teams, err := d.client.allTeams(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read DependencyTrack Teams",
@@ -82,7 +128,12 @@ func (d *teamsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, re
 	}
 
 	// Map response body to model
+	var ids, names []attr.Value
 	for _, team := range teams {
+		if !filter.matches(team.Name) || !hasPermission(team, state.HasPermission) {
+			continue
+		}
+
 		teamState := teamModel{
 			ID:   types.StringValue(team.UUID.String()),
 			Name: types.StringValue(team.Name),
@@ -95,12 +146,26 @@ func (d *teamsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, re
 		teamState.Permissions = types.SetValueMust(types.StringType, permissionNames)
 
 		state.Teams = append(state.Teams, teamState)
+		ids = append(ids, teamState.ID)
+		names = append(names, teamState.Name)
 	}
+	state.IDs = types.ListValueMust(types.StringType, ids)
+	state.Names = types.ListValueMust(types.StringType, names)
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// hasPermission returns if the team has the permission. A null permission matches all teams.
+func hasPermission(team dtrack.Team, permission types.String) bool {
+	if permission.IsNull() {
+		return true
+	}
+	return slices.ContainsFunc(team.Permissions, func(p dtrack.Permission) bool {
+		return p.Name == permission.ValueString()
+	})
+}
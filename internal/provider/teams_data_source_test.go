@@ -0,0 +1,69 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestTeamsDataSource(t *testing.T) {
+	server, cfg, mock := testServerWithMock(testServerVersion)
+	defer server.Close()
+	backendID := mock.addTeam("dev-backend", "VIEW_PORTFOLIO", "BOM_UPLOAD")
+	mock.addTeam("dev-frontend", "VIEW_PORTFOLIO")
+	mock.addTeam("ops")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: cfg + `data "dependencytrack_teams" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "teams.#", "3"),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "ids.#", "3"),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "names.#", "3"),
+				),
+			},
+			// Filter by name prefix testing
+			{
+				Config: cfg + `data "dependencytrack_teams" "test" { name_prefix = "dev-" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "names.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_teams.test", "names.*", "dev-backend"),
+					resource.TestCheckTypeSetElemAttr("data.dependencytrack_teams.test", "names.*", "dev-frontend"),
+				),
+			},
+			// Filter by name regex and permission testing
+			{
+				Config: cfg + `
+data "dependencytrack_teams" "test" {
+  name_regex     = "end$"
+  has_permission = "BOM_UPLOAD"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "teams.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "teams.0.id", backendID.String()),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "teams.0.name", "dev-backend"),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "ids.0", backendID.String()),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "names.0", "dev-backend"),
+				),
+			},
+			// No match testing
+			{
+				Config: cfg + `data "dependencytrack_teams" "test" { name_prefix = "qa-" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "ids.#", "0"),
+					resource.TestCheckResourceAttr("data.dependencytrack_teams.test", "names.#", "0"),
+				),
+			},
+			// Invalid regex testing
+			{
+				Config:      cfg + `data "dependencytrack_teams" "test" { name_regex = "(" }`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+		},
+	})
+}
@@ -1,28 +1,32 @@
 package provider
 
 import (
-	dtrack "github.com/DependencyTrack/client-go"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // teamsDataSource is the datasource implementation.
 type teamsDataSource struct {
-	client *dtrack.Client
+	client *apiClient
 }
 
 // teamDataSource is the datasource implementation.
 type teamDataSource struct {
-	client *dtrack.Client
+	client *apiClient
 }
 
 // teamResource is the resource implementation.
 type teamResource struct {
-	client *dtrack.Client
+	client *apiClient
 }
 
 // teamDataSourceModel maps the data source schema data.
 type teamsDataSourceModel struct {
-	Teams []teamModel `tfsdk:"teams"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	NamePrefix    types.String `tfsdk:"name_prefix"`
+	HasPermission types.String `tfsdk:"has_permission"`
+	IDs           types.List   `tfsdk:"ids"`
+	Names         types.List   `tfsdk:"names"`
+	Teams         []teamModel  `tfsdk:"teams"`
 }
 
 // teamModel maps oidc group schema data.
@@ -31,3 +35,45 @@ type teamModel struct {
 	Name        types.String `tfsdk:"name"`
 	Permissions types.Set    `tfsdk:"permissions"`
 }
+
+// teamDataSourceModel maps the team data source schema data.
+type teamDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Permissions      types.Set    `tfsdk:"permissions"`
+	APIKeyComments   types.List   `tfsdk:"api_key_comments"`
+	MappedOidcGroups types.Set    `tfsdk:"mapped_oidc_groups"`
+	MappedLdapGroups types.Set    `tfsdk:"mapped_ldap_groups"`
+	ManagedUsers     types.Set    `tfsdk:"managed_users"`
+	LdapUsers        types.Set    `tfsdk:"ldap_users"`
+	OidcUsers        types.Set    `tfsdk:"oidc_users"`
+}
+
+// teamResourceModel maps the team resource schema data.
+type teamResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Permissions             types.Set    `tfsdk:"permissions"`
+	ManagedUsers            types.Set    `tfsdk:"managed_users"`
+	LdapUsers               types.Set    `tfsdk:"ldap_users"`
+	OidcUsers               types.Set    `tfsdk:"oidc_users"`
+	MappedOidcGroups        types.Set    `tfsdk:"mapped_oidc_groups"`
+	MappedLdapGroups        types.Set    `tfsdk:"mapped_ldap_groups"`
+	AuthoritativeMembership types.Bool   `tfsdk:"authoritative_membership"`
+	AdoptExisting           types.Bool   `tfsdk:"adopt_existing"`
+	Fingerprint             types.String `tfsdk:"fingerprint"`
+}
+
+// teamResourceModelV0 maps the team resource schema data of version 0, without fingerprint.
+type teamResourceModelV0 struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Permissions             types.Set    `tfsdk:"permissions"`
+	ManagedUsers            types.Set    `tfsdk:"managed_users"`
+	LdapUsers               types.Set    `tfsdk:"ldap_users"`
+	OidcUsers               types.Set    `tfsdk:"oidc_users"`
+	MappedOidcGroups        types.Set    `tfsdk:"mapped_oidc_groups"`
+	MappedLdapGroups        types.Set    `tfsdk:"mapped_ldap_groups"`
+	AuthoritativeMembership types.Bool   `tfsdk:"authoritative_membership"`
+	AdoptExisting           types.Bool   `tfsdk:"adopt_existing"`
+}
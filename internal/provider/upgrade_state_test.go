@@ -0,0 +1,204 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeFixture is a resource state written by an older provider version, see testdata/upgrade.
+type upgradeFixture struct {
+	// ResourceType is the type of the resource, e.g. dependencytrack_team.
+	ResourceType string `json:"resource_type"`
+	// SchemaVersion is the schema version the state was written with.
+	SchemaVersion int64 `json:"schema_version"`
+	// State is the raw state as stored by Terraform. ${name} placeholders are replaced with the IDs of the mock objects.
+	State json.RawMessage `json:"state"`
+	// Expected are the attributes expected after upgrading and refreshing the state, attributes not listed are not verified.
+	Expected map[string]any `json:"expected"`
+}
+
+// TestUpgradeStateFixtures upgrades the resource states in testdata/upgrade and refreshes them against the mock server.
+func TestUpgradeStateFixtures(t *testing.T) {
+	server, _, mock := testServerWithMock(testServerVersion, dtrack.PermissionAccessManagement, dtrack.PermissionSystemConfiguration)
+	defer server.Close()
+	teamID := mock.addTeam("Developers", dtrack.PermissionBOMUpload)
+	groupID := mock.addOIDCGroup("developers")
+	mock.addOIDCMapping("developers", teamID)
+	placeholders := strings.NewReplacer(
+		"${repository_id}", testExistingUUID,
+		"${team_id}", teamID.String(),
+		"${oidc_group_id}", groupID.String(),
+	)
+
+	srv := configuredProviderServer(t, server.URL)
+	schemas, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join("testdata", "upgrade", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no upgrade fixtures found")
+	}
+	for _, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) {
+			b, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fixture upgradeFixture
+			if err := json.Unmarshal([]byte(placeholders.Replace(string(b))), &fixture); err != nil {
+				t.Fatal(err)
+			}
+			schema, ok := schemas.ResourceSchemas[fixture.ResourceType]
+			if !ok {
+				t.Fatalf("unknown resource type %s", fixture.ResourceType)
+			}
+			if fixture.SchemaVersion > schema.Version {
+				t.Fatalf("fixture schema version %d is newer than the schema version %d", fixture.SchemaVersion, schema.Version)
+			}
+
+			ctx := context.Background()
+			upgraded, err := srv.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: fixture.ResourceType,
+				Version:  fixture.SchemaVersion,
+				RawState: &tfprotov6.RawState{JSON: fixture.State},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, "upgrade", upgraded.Diagnostics)
+
+			read, err := srv.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     fixture.ResourceType,
+				CurrentState: upgraded.UpgradedState,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, "read", read.Diagnostics)
+
+			state, err := read.NewState.Unmarshal(schema.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.IsNull() {
+				t.Fatal("resource was removed from the state on read")
+			}
+			attributes, _ := goValue(state).(map[string]any)
+			for name, expected := range fixture.Expected {
+				actual, ok := attributes[name]
+				if !ok {
+					t.Errorf("attribute %s: not part of the schema", name)
+					continue
+				}
+				if !reflect.DeepEqual(actual, expected) {
+					t.Errorf("attribute %s: expected %#v, got %#v", name, expected, actual)
+				}
+			}
+			// All resources track the server-side object with a fingerprint, set on the first refresh after the upgrade
+			if fp, _ := attributes["fingerprint"].(string); fp == "" {
+				t.Error("attribute fingerprint: expected to be set on read")
+			}
+		})
+	}
+}
+
+// configuredProviderServer returns a provider server configured for the DependencyTrack server at host.
+func configuredProviderServer(t *testing.T, host string) tfprotov6.ProviderServer {
+	t.Helper()
+	ctx := context.Background()
+	srv, err := testAccProtoV6ProviderFactories["dependencytrack"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := srv.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typ := schemas.Provider.ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["host"] = tftypes.NewValue(tftypes.String, host)
+	values["token"] = tftypes.NewValue(tftypes.String, "foo")
+	config, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "configure", resp.Diagnostics)
+	return srv
+}
+
+// checkDiagnostics fails the test on error diagnostics.
+func checkDiagnostics(t *testing.T, op string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", op, d.Summary, d.Detail)
+		}
+	}
+}
+
+// goValue converts a value to its JSON decoded representation, sets are sorted.
+func goValue(v tftypes.Value) any {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return s
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		_ = v.As(&b)
+		return b
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		f, _ := n.Float64()
+		return f
+	case v.Type().Is(tftypes.List{}), v.Type().Is(tftypes.Set{}), v.Type().Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = v.As(&elems)
+		values := make([]any, 0, len(elems))
+		for _, e := range elems {
+			values = append(values, goValue(e))
+		}
+		if v.Type().Is(tftypes.Set{}) {
+			slices.SortFunc(values, func(a, b any) int { return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)) })
+		}
+		return values
+	default:
+		var attrs map[string]tftypes.Value
+		_ = v.As(&attrs)
+		values := make(map[string]any, len(attrs))
+		for name, a := range attrs {
+			values[name] = goValue(a)
+		}
+		return values
+	}
+}